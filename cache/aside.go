@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/hbttundar/scg-database/contract"
+)
+
+// Aside wraps a contract.Cache with singleflight coalescing, so that under
+// concurrent cache misses for the same key only one fetch runs and every
+// caller waiting on that key gets its result. It is the piece a Repository
+// implementation wires in to satisfy its cache-aside contract: consult the
+// cache before querying, invalidate on writes.
+type Aside struct {
+	Cache contract.Cache
+	group singleflight.Group
+}
+
+// NewAside wraps cache for coalesced cache-aside reads.
+func NewAside(cache contract.Cache) *Aside {
+	return &Aside{Cache: cache}
+}
+
+// Load returns the cached value for key if present, otherwise calls fetch
+// exactly once even under concurrent callers for the same key, caches its
+// result for ttl, and returns it.
+func (a *Aside) Load(ctx context.Context, key string, ttl time.Duration, fetch func(ctx context.Context) (any, error)) (any, error) {
+	if value, ok := a.Cache.Get(key); ok {
+		return value, nil
+	}
+
+	value, err, _ := a.group.Do(key, func() (any, error) {
+		value, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.Cache.Set(key, value, ttl); err != nil {
+			return nil, err
+		}
+		return value, nil
+	})
+	return value, err
+}
+
+// Invalidate removes every given key from the cache, e.g. from a
+// Create/Update/Delete hook so a stale read never follows a write.
+func (a *Aside) Invalidate(keys ...string) error {
+	for _, key := range keys {
+		if err := a.Cache.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Package cache provides contract.Cache implementations for Repository's
+// cache-aside layer, plus Aside, the singleflight-coalescing helper a
+// Repository uses to wire a Cache into its reads.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type lruEntry struct {
+	key      string
+	value    any
+	expires  time.Time
+	hasTTL   bool
+	listElem *list.Element
+}
+
+// LRU is an in-memory, size-bounded contract.Cache with optional per-entry
+// TTL. It evicts the least recently used entry once its capacity is exceeded.
+type LRU struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*lruEntry
+}
+
+// NewLRU returns an LRU that holds at most capacity entries.
+func NewLRU(capacity int) *LRU {
+	return &LRU{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*lruEntry),
+	}
+}
+
+func (c *LRU) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if entry.hasTTL && time.Now().After(entry.expires) {
+		c.removeLocked(entry)
+		return nil, false
+	}
+	c.order.MoveToFront(entry.listElem)
+	return entry.value, true
+}
+
+func (c *LRU) Set(key string, value any, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[key]; ok {
+		entry.value = value
+		entry.hasTTL = ttl > 0
+		entry.expires = time.Now().Add(ttl)
+		c.order.MoveToFront(entry.listElem)
+		return nil
+	}
+
+	entry := &lruEntry{key: key, value: value, hasTTL: ttl > 0, expires: time.Now().Add(ttl)}
+	entry.listElem = c.order.PushFront(entry)
+	c.entries[key] = entry
+
+	if c.capacity > 0 && len(c.entries) > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.removeLocked(oldest.Value.(*lruEntry))
+		}
+	}
+	return nil
+}
+
+func (c *LRU) Delete(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[key]; ok {
+		c.removeLocked(entry)
+	}
+	return nil
+}
+
+func (c *LRU) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.entries = make(map[string]*lruEntry)
+	return nil
+}
+
+// removeLocked removes entry from both the map and the LRU list. Callers
+// must hold c.mu.
+func (c *LRU) removeLocked(entry *lruEntry) {
+	c.order.Remove(entry.listElem)
+	delete(c.entries, entry.key)
+}
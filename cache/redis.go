@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis is a contract.Cache backed by a shared Redis instance via go-redis.
+// Values are JSON-encoded, so Get returns them as the generic shapes
+// encoding/json produces (map[string]any, []any, float64, ...) rather than
+// their original Go type.
+//
+// Every key is namespaced under keyPrefix so Flush, which must only ever
+// touch keys this Cache itself set, can scan for that prefix instead of
+// wiping the whole (possibly shared) Redis database.
+type Redis struct {
+	client    *redis.Client
+	ctx       context.Context
+	keyPrefix string
+}
+
+// NewRedis returns a Redis cache that issues commands through client using
+// ctx as the default context for calls that don't carry their own (Get,
+// Set, Delete and Flush are part of contract.Cache and so take no ctx).
+// keyPrefix namespaces every key this cache writes; pass one unique to this
+// cache's callers when client is shared with unrelated data, so Flush can't
+// ever delete anything outside that namespace.
+func NewRedis(client *redis.Client, ctx context.Context, keyPrefix string) *Redis {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &Redis{client: client, ctx: ctx, keyPrefix: keyPrefix}
+}
+
+func (r *Redis) key(key string) string {
+	return r.keyPrefix + key
+}
+
+func (r *Redis) Get(key string) (any, bool) {
+	data, err := r.client.Get(r.ctx, r.key(key)).Bytes()
+	if errors.Is(err, redis.Nil) || err != nil {
+		return nil, false
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+func (r *Redis) Set(key string, value any, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(r.ctx, r.key(key), data, ttl).Err()
+}
+
+func (r *Redis) Delete(key string) error {
+	return r.client.Del(r.ctx, r.key(key)).Err()
+}
+
+// Flush removes every key under keyPrefix by scanning for them and
+// UNLINKing them in batches, rather than FlushDB, which would wipe the
+// entire logical database including anything unrelated sharing it.
+func (r *Redis) Flush() error {
+	var cursor uint64
+	for {
+		keys, next, err := r.client.Scan(r.ctx, cursor, r.keyPrefix+"*", 100).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			if err := r.client.Unlink(r.ctx, keys...).Err(); err != nil {
+				return err
+			}
+		}
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+	return nil
+}
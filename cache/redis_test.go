@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedis(t *testing.T, keyPrefix string) (*Redis, *redis.Client) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return NewRedis(client, context.Background(), keyPrefix), client
+}
+
+func TestRedisFlushOnlyRemovesOwnPrefix(t *testing.T) {
+	c, client := newTestRedis(t, "scg:cache:")
+
+	if err := c.Set("widgets:1", "bolt", 0); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := client.Set(context.Background(), "other-app:key", "untouched", 0).Err(); err != nil {
+		t.Fatalf("seed unrelated key: %v", err)
+	}
+
+	if err := c.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	if _, ok := c.Get("widgets:1"); ok {
+		t.Fatalf("expected this cache's key to be gone after Flush")
+	}
+	if v, err := client.Get(context.Background(), "other-app:key").Result(); err != nil || v != "untouched" {
+		t.Fatalf("expected unrelated key to survive Flush, got %q err=%v", v, err)
+	}
+}
+
+func TestRedisGetSetDelete(t *testing.T) {
+	c, _ := newTestRedis(t, "scg:cache:")
+
+	if _, ok := c.Get("missing"); ok {
+		t.Fatalf("expected miss for unset key")
+	}
+
+	if err := c.Set("k", map[string]any{"n": float64(1)}, 0); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	value, ok := c.Get("k")
+	if !ok {
+		t.Fatalf("expected hit after Set")
+	}
+	if got := value.(map[string]any)["n"]; got != float64(1) {
+		t.Fatalf("unexpected value: %v", got)
+	}
+
+	if err := c.Delete("k"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok := c.Get("k"); ok {
+		t.Fatalf("expected miss after Delete")
+	}
+}
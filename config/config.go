@@ -0,0 +1,52 @@
+// Package config holds the connection configuration consumed by
+// contract.DBAdapter.Connect.
+package config
+
+import "time"
+
+type (
+	// Config describes a single logical database connection. Driver-specific
+	// adapters read the fields they need and ignore the rest.
+	Config struct {
+		Driver   string
+		DSN      string
+		Host     string
+		Port     int
+		Database string
+		Username string
+		Password string
+
+		MaxOpenConns    int
+		MaxIdleConns    int
+		ConnMaxLifetime time.Duration
+
+		// Resolver configures read/write splitting across additional
+		// physical connections. A nil Resolver means the adapter connects
+		// to this Config alone, as before.
+		Resolver *ResolverConfig
+	}
+
+	// SourceConfig describes one additional physical connection behind a
+	// Resolver.
+	SourceConfig struct {
+		Name   string
+		DSN    string
+		Weight int
+	}
+
+	// ResolverConfig configures read/write splitting for a Config. Replicas
+	// receive read traffic per Strategy; writes and anything inside
+	// Connection.Transaction always use the Config this is attached to.
+	ResolverConfig struct {
+		Replicas []SourceConfig
+		Strategy string // "round_robin" or "weighted", see contract.Strategy
+
+		// HealthCheckInterval is how often quarantined and active replicas
+		// are probed.
+		HealthCheckInterval time.Duration
+
+		// QuarantineBackoff is how long a replica that fails its health
+		// check is excluded from rotation before being retried.
+		QuarantineBackoff time.Duration
+	}
+)
@@ -0,0 +1,62 @@
+// Package connbase provides Base, the RegisterObserver/Cache/Outbox
+// bookkeeping shared by every contract.Connection implementation in this
+// repo, so each one only has to embed it instead of reimplementing the
+// same map-and-mutex logic.
+package connbase
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/hbttundar/scg-database/contract"
+)
+
+// Base implements the non-I/O parts of contract.Connection: observer
+// registration and the configured Cache/OutboxStore. Embed it in a
+// concrete Connection and call NewBase from its constructor.
+type Base struct {
+	mu        sync.RWMutex
+	observers map[reflect.Type][]contract.Observer
+	cache     contract.Cache
+	outbox    contract.OutboxStore
+}
+
+// NewBase resolves opts (see contract.WithCache, contract.WithOutbox) into
+// a ready-to-embed Base.
+func NewBase(opts ...contract.ConnectOption) Base {
+	outbox, cache := contract.ApplyConnectOptions(opts...)
+	return Base{
+		observers: make(map[reflect.Type][]contract.Observer),
+		cache:     cache,
+		outbox:    outbox,
+	}
+}
+
+func modelType(model contract.Model) reflect.Type {
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func (b *Base) RegisterObserver(model contract.Model, obs contract.Observer) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	key := modelType(model)
+	b.observers[key] = append(b.observers[key], obs)
+}
+
+func (b *Base) ObserversFor(model contract.Model) []contract.Observer {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]contract.Observer(nil), b.observers[modelType(model)]...)
+}
+
+func (b *Base) Cache() (contract.Cache, bool) {
+	return b.cache, b.cache != nil
+}
+
+func (b *Base) Outbox() (contract.OutboxStore, bool) {
+	return b.outbox, b.outbox != nil
+}
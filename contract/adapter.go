@@ -7,8 +7,11 @@ import (
 type (
 	DBAdapter interface {
 		// Connect's only job is to create our rich Connection object from a config struct.
-		// Options should be applied *before* this is called.
-		Connect(cfg *config.Config) (Connection, error)
+		// If cfg.Resolver is set, the returned Connection fans reads out across the
+		// configured replicas and routes writes, Statement calls and anything inside
+		// Transaction to the primary. opts are resolved via ApplyConnectOptions; e.g.
+		// WithOutbox(store) makes writes inside Transaction also append to store.
+		Connect(cfg *config.Config, opts ...ConnectOption) (Connection, error)
 		Name() string
 	}
 )
@@ -5,10 +5,18 @@ import (
 )
 
 type (
+	// Cache is a key/value store a Repository consults for cache-aside
+	// reads and invalidates on writes. Implementations backed by a shared
+	// store (e.g. a Redis instance also used for other things) must scope
+	// Flush to only the keys this Cache itself has set.
 	Cache interface {
 		Get(key string) (any, bool)
 		Set(key string, value any, ttl time.Duration) error
 		Delete(key string) error
+
+		// Flush removes every key this Cache has set. It must never affect
+		// keys it did not itself write, even when the underlying store is
+		// shared with unrelated data.
 		Flush() error
 	}
 )
@@ -12,6 +12,26 @@ type (
 		Close() error
 		NewRepository(model Model) (Repository, error)
 		Transaction(ctx context.Context, fn func(txConnection Connection) error) error
+
+		// RegisterObserver attaches obs to every Repository mutation and
+		// find performed against model, in addition to whichever hook
+		// interfaces (see hooks.go) model itself implements. Observers run
+		// in registration order, after the model's own hooks.
+		RegisterObserver(model Model, obs Observer)
+
+		// ObserversFor returns the Observers registered against model's
+		// type, in registration order.
+		ObserversFor(model Model) []Observer
+
+		// Cache returns the Cache configured for this Connection, if any
+		// (see WithCache). Repository implementations use it for
+		// cache-aside reads and write invalidation.
+		Cache() (Cache, bool)
+
+		// Outbox returns the OutboxStore configured for this Connection, if
+		// any (see WithOutbox).
+		Outbox() (OutboxStore, bool)
+
 		Select(ctx context.Context, query string, bindings ...any) ([]map[string]any, error)
 		Statement(ctx context.Context, query string, bindings ...any) (sql.Result, error)
 	}
@@ -0,0 +1,46 @@
+package contract
+
+import (
+	"context"
+)
+
+// Hook interfaces are each satisfied independently, so a Model or Observer
+// implements only the ones it needs instead of a single all-or-nothing
+// interface. Repository type-asserts for each interface at the
+// corresponding point in a mutation or find and calls it only if present.
+// A Before* hook returning an error aborts the operation (and rolls back
+// the active transaction, if any). After* hooks run after the write but
+// inside the same transaction, so an After* hook error is returned to the
+// caller and rolls that transaction back the same way.
+type (
+	BeforeCreator interface {
+		BeforeCreate(ctx context.Context, tx Connection) error
+	}
+	AfterCreator interface {
+		AfterCreate(ctx context.Context, tx Connection) error
+	}
+	BeforeUpdater interface {
+		BeforeUpdate(ctx context.Context, tx Connection) error
+	}
+	AfterUpdater interface {
+		AfterUpdate(ctx context.Context, tx Connection) error
+	}
+	BeforeDeleter interface {
+		BeforeDelete(ctx context.Context, tx Connection) error
+	}
+	AfterDeleter interface {
+		AfterDelete(ctx context.Context, tx Connection) error
+	}
+	AfterFinder interface {
+		AfterFind(ctx context.Context, tx Connection) error
+	}
+)
+
+// Observer is registered against a Model type to react to its Repository
+// mutations without the model itself implementing the hook interfaces
+// above, e.g. for cross-cutting concerns like auditing or cache
+// invalidation. It implements whichever of BeforeCreator, AfterCreator,
+// BeforeUpdater, AfterUpdater, BeforeDeleter, AfterDeleter and AfterFinder
+// it needs; Repository type-asserts for each the same way it does for
+// Models.
+type Observer any
@@ -0,0 +1,8 @@
+package contract
+
+// Model is implemented by every struct a Repository manages. TableName
+// names the table it maps to; struct tags on its fields (see package
+// schema) describe the columns within that table.
+type Model interface {
+	TableName() string
+}
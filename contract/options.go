@@ -0,0 +1,37 @@
+package contract
+
+type connectOptions struct {
+	Outbox OutboxStore
+	Cache  Cache
+}
+
+// ConnectOption customizes a Connection at the point a DBAdapter creates it.
+type ConnectOption func(*connectOptions)
+
+// WithOutbox makes every Create/Update/Delete performed inside
+// Connection.Transaction also append a serialized OutboxEvent to store, in
+// the same sql.Tx as the write itself.
+func WithOutbox(store OutboxStore) ConnectOption {
+	return func(o *connectOptions) {
+		o.Outbox = store
+	}
+}
+
+// WithCache configures the Cache a Connection's Repositories use for
+// cache-aside reads and write invalidation.
+func WithCache(cache Cache) ConnectOption {
+	return func(o *connectOptions) {
+		o.Cache = cache
+	}
+}
+
+// ApplyConnectOptions folds opts into a fresh connectOptions value. DBAdapter
+// and Connection implementations call this to resolve the options a caller
+// passed in.
+func ApplyConnectOptions(opts ...ConnectOption) (outbox OutboxStore, cache Cache) {
+	var resolved connectOptions
+	for _, opt := range opts {
+		opt(&resolved)
+	}
+	return resolved.Outbox, resolved.Cache
+}
@@ -0,0 +1,60 @@
+package contract
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// OutboxEvent is a single row of the outbox table: a domain event
+	// captured in the same transaction as the write that produced it, to be
+	// published at-least-once by an OutboxDispatcher.
+	OutboxEvent struct {
+		ID            string
+		AggregateType string
+		AggregateID   string
+		EventType     string
+		Payload       []byte
+		CreatedAt     time.Time
+		Attempts      int
+
+		// LastAttemptAt is the time of the most recent failed publish
+		// attempt, zero if none has happened yet. OutboxDispatcher bases
+		// its exponential backoff on this, not CreatedAt, so the delay is
+		// actually between retries rather than a one-time grace period
+		// after creation.
+		LastAttemptAt time.Time
+	}
+
+	// OutboxStore persists OutboxEvents and tracks their delivery state. The
+	// DBAdapter-specific implementation must make Append participate in the
+	// same sql.Tx as the Repository write it accompanies, which is why it
+	// takes the in-flight transaction Connection rather than opening its own.
+	OutboxStore interface {
+		// Append inserts event as part of tx, so it is only durable if tx
+		// commits.
+		Append(ctx context.Context, tx Connection, event OutboxEvent) error
+
+		// FetchUndelivered returns up to limit events that have not yet
+		// been marked delivered, oldest first.
+		FetchUndelivered(ctx context.Context, limit int) ([]OutboxEvent, error)
+
+		// MarkDelivered marks the given events as successfully published.
+		MarkDelivered(ctx context.Context, ids ...string) error
+
+		// MarkFailed records a failed publish attempt for id, incrementing
+		// its Attempts counter.
+		MarkFailed(ctx context.Context, id string, cause error) error
+
+		// Poison moves event past the retry limit into a dead-letter table
+		// for manual inspection, removing it from FetchUndelivered results.
+		Poison(ctx context.Context, id string, cause error) error
+	}
+
+	// Publisher hands a delivered OutboxEvent off to an external system
+	// (Kafka, NATS, a webhook, ...). Returning an error leaves the event
+	// undelivered for the OutboxDispatcher to retry.
+	Publisher interface {
+		Publish(ctx context.Context, event OutboxEvent) error
+	}
+)
@@ -0,0 +1,44 @@
+package contract
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+type (
+	// Page is the result of a keyset-paginated query. Unlike offset-based
+	// pagination it carries no total count: NextCursor/PrevCursor are
+	// opaque tokens the caller passes back to continue in either direction,
+	// and HasMore tells it whether NextCursor is worth following.
+	Page struct {
+		Items      any
+		NextCursor string
+		PrevCursor string
+		HasMore    bool
+	}
+)
+
+// EncodeCursor packs an ordered tuple of ordering-column values into the
+// opaque base64 token used for Page.NextCursor/PrevCursor, so callers never
+// need to understand or tamper with its contents.
+func EncodeCursor(values ...any) (string, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor reverses EncodeCursor, returning the ordering-column values
+// that were encoded into token.
+func DecodeCursor(token string) ([]any, error) {
+	data, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	var values []any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
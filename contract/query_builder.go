@@ -33,6 +33,34 @@ type (
 		Limit(limit int) QueryBuilder
 		Offset(offset int) QueryBuilder
 
+		// Cursor adds a keyset pagination boundary on column, ordering by it
+		// in direction ("asc" or "desc"); see Repository.Cursor for the
+		// composite-key usage.
+		Cursor(column string, lastValue any, direction string) QueryBuilder
+
+		// Backward flips the boundary operator and ORDER BY set up by
+		// Cursor for the next Paginate call only, so that call fetches the
+		// page before the boundary instead of the page after it. Results
+		// are still returned in the original (forward) order; only which
+		// side of the boundary Paginate fetches is affected. Pass the
+		// lastValue decoded from a Page's PrevCursor to page backward, or
+		// from its NextCursor (without calling Backward) to page forward.
+		Backward() QueryBuilder
+
+		// Paginate runs the current query as a keyset-paginated page of at
+		// most size items, scanning the page's items into dest the same way
+		// Get does. HasMore reports whether there is another page in the
+		// direction just queried (forward by default, or backward after
+		// Backward): follow Page.NextCursor after a forward Paginate, or
+		// Page.PrevCursor after a backward one.
+		Paginate(ctx context.Context, size int, dest any) (*Page, error)
+
+		// CountEstimate returns an approximate row count for the current
+		// query's table (e.g. via EXPLAIN or pg_class.reltuples on
+		// Postgres) instead of an exact COUNT(*), since an exact count on a
+		// large table defeats the point of keyset paging.
+		CountEstimate(ctx context.Context) (int64, error)
+
 		// Relationships
 		With(relations ...string) QueryBuilder
 		WithCount(relations ...string) QueryBuilder
@@ -75,4 +103,28 @@ type (
 		Get(adapterName string) (QueryBuilderFactory, error)
 		List() []string
 	}
+
+	// PlaceholderStyle is the bind-parameter syntax a dialect expects.
+	PlaceholderStyle string
+
+	// Dialect is the minimal set of SQL-generation facts a DBAdapter must
+	// supply so an adapter-agnostic QueryBuilder (see querybuilder/squirrel)
+	// can produce correct SQL without knowing the underlying driver.
+	Dialect struct {
+		Placeholder       PlaceholderStyle
+		Quote             string // identifier quote character, e.g. `"` or "`"
+		SupportsReturning bool
+	}
+
+	// DialectProvider is implemented by adapters that want the fallback
+	// QueryBuilder registered by QueryBuilderRegistry to generate correctly
+	// quoted, correctly parameterized SQL for their driver.
+	DialectProvider interface {
+		Dialect() Dialect
+	}
+)
+
+const (
+	PlaceholderQuestion PlaceholderStyle = "question" // "?"
+	PlaceholderDollar   PlaceholderStyle = "dollar"   // "$1", "$2", ...
 )
@@ -13,6 +13,30 @@ type (
 		Offset(offset int) Repository
 		OrderBy(column, direction string) Repository
 
+		// Cursor adds a keyset pagination boundary on column, ordering by it
+		// in direction ("asc" or "desc"). Call it once per ordering column,
+		// in the same order as the corresponding OrderBy calls, to page on
+		// a composite key. lastValue is typically decoded from a Page's
+		// NextCursor/PrevCursor via DecodeCursor.
+		Cursor(column string, lastValue any, direction string) Repository
+
+		// Backward returns a Repository whose Paginate calls flip the
+		// boundary operator and ORDER BY set up by Cursor, fetching the
+		// page before the boundary; see QueryBuilder.Backward.
+		Backward() Repository
+
+		// NoCache scopes off the Connection's configured Cache for the rest
+		// of this call chain, forcing Find/FindOrFail to hit the database
+		// even when a cache entry would otherwise satisfy them.
+		NoCache() Repository
+
+		// Find, FindOrFail, First, FirstOrFail and Get each invoke AfterFind
+		// on every Model they return that implements AfterFinder, plus any
+		// registered Observer that does. Find and FindOrFail additionally
+		// consult the Connection's configured Cache (keyed by table and
+		// primary key) before querying, unless NoCache was called. First,
+		// FirstOrFail and Get always query the database; they neither read
+		// nor fill the Cache.
 		Find(ctx context.Context, id any) (Model, error)
 		FindOrFail(ctx context.Context, id any) (Model, error)
 		First(ctx context.Context) (Model, error)
@@ -20,6 +44,21 @@ type (
 		Get(ctx context.Context) ([]Model, error)
 		Pluck(ctx context.Context, column string, dest any) error
 
+		// Paginate runs the current query as a keyset-paginated page of at
+		// most size items, using whatever Cursor/OrderBy/Backward
+		// boundaries have been set. It replaces Limit/Offset paging on
+		// large tables, whose cost grows with the offset instead of
+		// staying O(log n).
+		Paginate(ctx context.Context, size int) (*Page, error)
+
+		// Create, CreateInBatches, Update, Delete and ForceDelete each run
+		// inside a transaction that wraps the corresponding Before*/After*
+		// hook interfaces (BeforeCreator/AfterCreator, etc., and any
+		// registered Observer implementing them) around every Model in the
+		// call. A Before* hook error aborts the whole call and rolls back
+		// the transaction before any row is written. On success, each
+		// invalidates the affected Models' entries in the Connection's
+		// configured Cache.
 		Create(ctx context.Context, models ...Model) error
 		CreateInBatches(ctx context.Context, models []Model, batchSize int) error
 		Update(ctx context.Context, models ...Model) error
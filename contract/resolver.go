@@ -0,0 +1,89 @@
+package contract
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// SourceRole identifies whether a physical connection behind a Resolver
+	// accepts writes (Primary) or read-only traffic (Replica).
+	SourceRole string
+
+	// Strategy selects how a Resolver distributes read traffic across its
+	// healthy replicas.
+	Strategy string
+)
+
+const (
+	RolePrimary SourceRole = "primary"
+	RoleReplica SourceRole = "replica"
+
+	StrategyRoundRobin Strategy = "round_robin"
+	StrategyWeighted   Strategy = "weighted"
+)
+
+type (
+	// Source is a single physical Connection registered with a Resolver,
+	// along with the metadata the Resolver needs to route to and monitor it.
+	Source struct {
+		Name   string
+		Role   SourceRole
+		Conn   Connection
+		Weight int
+	}
+
+	// HealthChecker probes a Source and reports whether it is fit to receive
+	// traffic. Resolver implementations call it on a schedule to decide when
+	// to quarantine or restore a replica.
+	HealthChecker interface {
+		Check(ctx context.Context, source Source) error
+	}
+
+	// Resolver fans a single logical Connection out over one primary and N
+	// replica Sources. Reads are routed to a healthy replica, writes and
+	// anything running inside Connection.Transaction are routed to the
+	// primary, and a failed replica is quarantined for a backoff period
+	// before it is eligible for traffic again.
+	Resolver interface {
+		// AddSource registers a physical Connection under the given role.
+		// Weight is only consulted by StrategyWeighted and is ignored for
+		// the primary.
+		AddSource(source Source) error
+
+		// Remove unregisters a previously added Source by name.
+		Remove(name string) error
+
+		// Primary returns the connection that writes and pinned reads
+		// (see WithPrimary) must use.
+		Primary(ctx context.Context) (Connection, error)
+
+		// Replica returns a healthy replica connection chosen according to
+		// the Resolver's Strategy, falling back to Primary if every
+		// replica is quarantined.
+		Replica(ctx context.Context) (Connection, error)
+
+		// Quarantine takes a Source out of rotation for the given backoff
+		// duration, after which it becomes eligible for traffic again.
+		Quarantine(name string, backoff time.Duration)
+
+		// Healthy reports whether the named Source is currently eligible
+		// for traffic.
+		Healthy(name string) bool
+	}
+)
+
+type primaryKey struct{}
+
+// WithPrimary marks ctx so that a Resolver-backed Connection routes the
+// call it carries to the primary Source even when the call would normally
+// be eligible for replica routing (e.g. a read-only Select).
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// IsPrimary reports whether ctx was marked with WithPrimary.
+func IsPrimary(ctx context.Context) bool {
+	pinned, _ := ctx.Value(primaryKey{}).(bool)
+	return pinned
+}
@@ -0,0 +1,69 @@
+package contract
+
+import (
+	"context"
+)
+
+type (
+	// ColumnSpec describes one column of a TableSpec, as parsed from a
+	// Model field's `db` struct tag (e.g. `db:"name,pk,size=100,index,unique,fk=users.id"`).
+	ColumnSpec struct {
+		Name       string
+		GoType     string // the Go field type, e.g. "string", "int64", "time.Time"
+		PrimaryKey bool
+		Size       int
+		Index      bool
+		Unique     bool
+		ForeignKey string // "table.column", empty if none
+		Nullable   bool
+	}
+
+	// TableSpec is the schema SchemaSyncer.Sync derives from a Model's
+	// struct tags, to be diffed against the live database.
+	TableSpec struct {
+		Name    string
+		Columns []ColumnSpec
+	}
+
+	// Plan is the set of DDL statements SchemaSyncer.Sync would need to run
+	// to bring the database in line with the Models it was given. It is
+	// inert until passed to SchemaSyncer.Apply, so DryRun callers can
+	// inspect or log it first.
+	Plan struct {
+		Statements  []string
+		Destructive bool // true if any statement drops a column or table
+	}
+
+	// DDLRenderer supplies the dialect-specific SQL a SchemaSyncer needs to
+	// turn a diff into DDL. Each DBAdapter that wants auto-migration support
+	// provides one tuned to its database (Postgres/MySQL/SQLite type
+	// mapping, identifier quoting, etc).
+	DDLRenderer interface {
+		// ColumnType maps a ColumnSpec's Go type (and Size, where relevant)
+		// to this dialect's column type, e.g. "string" + Size 100 -> "VARCHAR(100)".
+		ColumnType(col ColumnSpec) string
+
+		CreateTable(table TableSpec) string
+		AddColumn(table string, col ColumnSpec) string
+		CreateIndex(table string, col ColumnSpec) string
+		DropColumn(table, column string) string
+		DropTable(table string) string
+	}
+
+	// SchemaSyncer complements the file-based Migrator with auto-migration
+	// derived from Model struct tags: Sync inspects live information_schema
+	// state and produces a Plan, Apply executes it.
+	SchemaSyncer interface {
+		// Sync compares models against the live schema and returns the Plan
+		// of DDL statements needed to reconcile them. It never executes
+		// anything itself, so it's always safe to call, including in
+		// DryRun mode.
+		Sync(ctx context.Context, models ...Model) (Plan, error)
+
+		// Apply executes plan's statements. If the syncer's Destructive
+		// flag is false, Apply returns an error instead of running a Plan
+		// whose Destructive field is true, so dropping a column or table
+		// always requires explicit opt-in.
+		Apply(ctx context.Context, plan Plan) error
+	}
+)
@@ -0,0 +1,104 @@
+// Package outbox provides a background dispatcher that publishes rows
+// written by the transactional outbox (see contract.WithOutbox) with
+// at-least-once delivery.
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/hbttundar/scg-database/contract"
+)
+
+// Dispatcher polls a contract.OutboxStore for undelivered events and hands
+// each to a contract.Publisher, retrying with exponential backoff and
+// poisoning events that exceed MaxAttempts.
+type Dispatcher struct {
+	Store        contract.OutboxStore
+	Publisher    contract.Publisher
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+}
+
+// NewDispatcher returns a Dispatcher with reasonable defaults for
+// PollInterval, BatchSize, MaxAttempts and BaseBackoff; override any field
+// on the returned value before calling Run.
+func NewDispatcher(store contract.OutboxStore, publisher contract.Publisher) *Dispatcher {
+	return &Dispatcher{
+		Store:        store,
+		Publisher:    publisher,
+		PollInterval: time.Second,
+		BatchSize:    100,
+		MaxAttempts:  5,
+		BaseBackoff:  time.Second,
+	}
+}
+
+// Run polls Store every PollInterval until ctx is canceled, dispatching each
+// batch it finds. It returns ctx.Err() when ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := d.DispatchOnce(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// DispatchOnce fetches and publishes a single batch of undelivered events.
+// It never returns a per-event publish error: those are recorded on the
+// event itself via MarkFailed or Poison so the next poll can retry.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) error {
+	events, err := d.Store.FetchUndelivered(ctx, d.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	var delivered []string
+	for _, event := range events {
+		if !d.dueForRetry(event) {
+			continue
+		}
+		if err := d.Publisher.Publish(ctx, event); err != nil {
+			d.handleFailure(ctx, event, err)
+			continue
+		}
+		delivered = append(delivered, event.ID)
+	}
+
+	if len(delivered) == 0 {
+		return nil
+	}
+	return d.Store.MarkDelivered(ctx, delivered...)
+}
+
+func (d *Dispatcher) handleFailure(ctx context.Context, event contract.OutboxEvent, cause error) {
+	if event.Attempts+1 >= d.MaxAttempts {
+		_ = d.Store.Poison(ctx, event.ID, cause)
+		return
+	}
+	_ = d.Store.MarkFailed(ctx, event.ID, cause)
+}
+
+// dueForRetry reports whether event has waited out its exponential backoff
+// since its last attempt, not since it was created -- otherwise once the
+// cumulative time since creation passes the current tier, every poll tick
+// would redeliver it instead of waiting out an actual delay between
+// retries. A zero LastAttemptAt means no attempt has happened yet, which
+// Attempts == 0 already covers below.
+func (d *Dispatcher) dueForRetry(event contract.OutboxEvent) bool {
+	if event.Attempts == 0 {
+		return true
+	}
+	backoff := d.BaseBackoff << uint(event.Attempts-1)
+	return time.Since(event.LastAttemptAt) >= backoff
+}
@@ -0,0 +1,52 @@
+package outbox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hbttundar/scg-database/contract"
+)
+
+func TestDueForRetryWaitsOutBackoffSinceLastAttempt(t *testing.T) {
+	d := NewDispatcher(nil, nil)
+	d.BaseBackoff = time.Second
+
+	event := contract.OutboxEvent{
+		CreatedAt:     time.Now().Add(-time.Hour),
+		Attempts:      1,
+		LastAttemptAt: time.Now(),
+	}
+
+	if d.dueForRetry(event) {
+		t.Fatalf("expected event to still be within backoff of its last attempt")
+	}
+
+	event.LastAttemptAt = time.Now().Add(-2 * time.Second)
+	if !d.dueForRetry(event) {
+		t.Fatalf("expected event to be due once backoff since last attempt has elapsed")
+	}
+}
+
+func TestDueForRetryAlwaysTrueForFirstAttempt(t *testing.T) {
+	d := NewDispatcher(nil, nil)
+
+	event := contract.OutboxEvent{CreatedAt: time.Now(), Attempts: 0}
+	if !d.dueForRetry(event) {
+		t.Fatalf("expected a never-attempted event to be due immediately")
+	}
+}
+
+func TestDueForRetryBackoffGrowsWithAttempts(t *testing.T) {
+	d := NewDispatcher(nil, nil)
+	d.BaseBackoff = time.Second
+
+	// After three attempts, backoff should be 4x BaseBackoff; a last attempt
+	// 2x BaseBackoff ago must not be due yet.
+	event := contract.OutboxEvent{
+		Attempts:      3,
+		LastAttemptAt: time.Now().Add(-2 * time.Second),
+	}
+	if d.dueForRetry(event) {
+		t.Fatalf("expected higher attempt counts to wait out a longer backoff")
+	}
+}
@@ -0,0 +1,61 @@
+// Package querybuilder provides the default contract.QueryBuilderRegistry
+// used to look up the contract.QueryBuilderFactory for a given adapter.
+package querybuilder
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hbttundar/scg-database/contract"
+	"github.com/hbttundar/scg-database/querybuilder/squirrel"
+)
+
+// registry is a concurrency-safe, map-backed contract.QueryBuilderRegistry.
+type registry struct {
+	mu        sync.RWMutex
+	factories map[string]contract.QueryBuilderFactory
+}
+
+// NewRegistry returns an empty contract.QueryBuilderRegistry.
+func NewRegistry() contract.QueryBuilderRegistry {
+	return &registry{factories: make(map[string]contract.QueryBuilderFactory)}
+}
+
+func (r *registry) Register(adapterName string, factory contract.QueryBuilderFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[adapterName] = factory
+}
+
+func (r *registry) Get(adapterName string) (contract.QueryBuilderFactory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	factory, ok := r.factories[adapterName]
+	if !ok {
+		return nil, fmt.Errorf("querybuilder: no factory registered for adapter %q", adapterName)
+	}
+	return factory, nil
+}
+
+func (r *registry) List() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	return names
+}
+
+// RegisterFallback registers the squirrel-backed factory for adapterName
+// unless a native factory is already registered for it. DBAdapter
+// implementations that don't ship their own QueryBuilder should call this
+// from their Connect method with their own contract.Dialect, e.g.:
+//
+//	querybuilder.RegisterFallback(registry, adapter.Name(), adapter.Dialect())
+func RegisterFallback(r contract.QueryBuilderRegistry, adapterName string, dialect contract.Dialect) {
+	if _, err := r.Get(adapterName); err == nil {
+		return
+	}
+	r.Register(adapterName, squirrel.Factory(dialect))
+}
@@ -0,0 +1,132 @@
+package squirrel
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// scanRows copies rows into dest, which must be a pointer to a struct (for a
+// single row) or a pointer to a slice of structs/pointers-to-structs (for
+// multiple rows). Columns are matched to struct fields by a "db" tag, or by
+// case-insensitive field name when the tag is absent.
+func scanRows(rows *sql.Rows, dest any) error {
+	destVal := reflect.ValueOf(dest)
+	if destVal.Kind() != reflect.Ptr || destVal.IsNil() {
+		return fmt.Errorf("squirrel: dest must be a non-nil pointer")
+	}
+	elem := destVal.Elem()
+
+	if elem.Kind() == reflect.Slice {
+		itemType := elem.Type().Elem()
+		for rows.Next() {
+			itemPtr := reflect.New(derefType(itemType))
+			if err := scanRowInto(rows, itemPtr); err != nil {
+				return err
+			}
+			if itemType.Kind() == reflect.Ptr {
+				elem.Set(reflect.Append(elem, itemPtr))
+			} else {
+				elem.Set(reflect.Append(elem, itemPtr.Elem()))
+			}
+		}
+		return rows.Err()
+	}
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	return scanRowInto(rows, destVal)
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+func scanRowInto(rows *sql.Rows, structPtr reflect.Value) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	target := structPtr.Elem()
+	fields := fieldsByColumn(target.Type())
+
+	values := make([]any, len(columns))
+	for i, col := range columns {
+		if fieldIndex, ok := fields[strings.ToLower(col)]; ok {
+			values[i] = target.Field(fieldIndex).Addr().Interface()
+		} else {
+			values[i] = new(any)
+		}
+	}
+	return rows.Scan(values...)
+}
+
+func fieldsByColumn(t reflect.Type) map[string]int {
+	out := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Tag.Get("db")
+		if name == "" {
+			name = field.Name
+		}
+		name = strings.Split(name, ",")[0]
+		out[strings.ToLower(name)] = i
+	}
+	return out
+}
+
+// columnsAndValues flattens a struct (or map[string]any) into parallel
+// column/value slices suitable for an INSERT or UPDATE, using the same "db"
+// tag convention as scanRows.
+func columnsAndValues(value any) ([]string, []any, error) {
+	if m, ok := value.(map[string]any); ok {
+		cols := make([]string, 0, len(m))
+		vals := make([]any, 0, len(m))
+		for k, v := range m {
+			cols = append(cols, k)
+			vals = append(vals, v)
+		}
+		return cols, vals, nil
+	}
+
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("squirrel: cannot derive columns from %T", value)
+	}
+
+	t := v.Type()
+	cols := make([]string, 0, t.NumField())
+	vals := make([]any, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Tag.Get("db")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		name = strings.Split(name, ",")[0]
+		cols = append(cols, name)
+		vals = append(vals, v.Field(i).Interface())
+	}
+	return cols, vals, nil
+}
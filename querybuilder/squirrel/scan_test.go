@@ -0,0 +1,46 @@
+package squirrel
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type widget struct {
+	ID       int64  `db:"id,pk"`
+	Name     string `db:"name"`
+	mu       sync.Mutex
+	internal string
+}
+
+func TestColumnsAndValuesSkipsUnexportedFields(t *testing.T) {
+	w := &widget{ID: 1, Name: "bolt", internal: "unused"}
+
+	cols, vals, err := columnsAndValues(w)
+	if err != nil {
+		t.Fatalf("columnsAndValues: %v", err)
+	}
+
+	if len(cols) != 2 {
+		t.Fatalf("expected 2 columns, got %d: %v", len(cols), cols)
+	}
+	for _, col := range cols {
+		if col == "mu" || col == "internal" {
+			t.Fatalf("unexported field leaked into columns: %v", cols)
+		}
+	}
+	_ = vals
+}
+
+func TestFieldsByColumnSkipsUnexportedFields(t *testing.T) {
+	fields := fieldsByColumn(reflect.TypeOf(widget{}))
+	if _, ok := fields["mu"]; ok {
+		t.Fatalf("unexported field mu leaked into fieldsByColumn")
+	}
+	if _, ok := fields["internal"]; ok {
+		t.Fatalf("unexported field internal leaked into fieldsByColumn")
+	}
+	if _, ok := fields["id"]; !ok {
+		t.Fatalf("expected exported field id to be present")
+	}
+}
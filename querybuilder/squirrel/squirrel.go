@@ -0,0 +1,585 @@
+// Package squirrel provides a default, adapter-agnostic
+// contract.QueryBuilder implementation on top of Masterminds/squirrel. A
+// DBAdapter that doesn't ship its own query builder can register this one
+// as a fallback via querybuilder.RegisterFallback, supplying only a
+// contract.Dialect to get correctly quoted, correctly parameterized SQL.
+package squirrel
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/hbttundar/scg-database/contract"
+)
+
+// executor is the subset of *sql.DB / *sql.Tx this builder needs to run the
+// SQL it generates. Any connection passed to NewQueryBuilder must satisfy it.
+type executor interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type whereClause struct {
+	or   bool
+	cond sq.Sqlizer
+}
+
+type cursorBound struct {
+	column    string
+	value     any
+	direction string
+}
+
+type orderEntry struct {
+	column     string
+	direction  string
+	fromCursor bool
+}
+
+// builder is the squirrel-backed contract.QueryBuilder. Methods mutate the
+// receiver and return it; use Clone to branch off a copy.
+type builder struct {
+	model   contract.Model
+	exec    executor
+	dialect contract.Dialect
+	sb      sq.StatementBuilderType
+
+	table      string
+	columns    []string
+	wheres     []whereClause
+	joins      []string
+	orderBys   []orderEntry
+	groupBys   []string
+	havings    []whereClause
+	limit      *uint64
+	offset     *uint64
+	cursors    []cursorBound
+	backward   bool
+	relations  []string
+	withCounts []string
+	unscoped   bool
+	rawSQL     string
+	rawArgs    []any
+}
+
+// Factory returns a contract.QueryBuilderFactory backed by this package for
+// the given dialect.
+func Factory(dialect contract.Dialect) contract.QueryBuilderFactory {
+	return &factory{dialect: dialect}
+}
+
+type factory struct {
+	dialect contract.Dialect
+}
+
+func (f *factory) Name() string { return "squirrel" }
+
+func (f *factory) NewQueryBuilder(model contract.Model, connection any) contract.QueryBuilder {
+	return New(model, connection, f.dialect)
+}
+
+// New builds a contract.QueryBuilder for model, executing through
+// connection (which must satisfy executor) using dialect to render SQL.
+func New(model contract.Model, connection any, dialect contract.Dialect) contract.QueryBuilder {
+	var placeholder sq.PlaceholderFormat = sq.Question
+	if dialect.Placeholder == contract.PlaceholderDollar {
+		placeholder = sq.Dollar
+	}
+
+	b := &builder{
+		model:   model,
+		dialect: dialect,
+		sb:      sq.StatementBuilder.PlaceholderFormat(placeholder),
+	}
+	if exec, ok := connection.(executor); ok {
+		b.exec = exec
+	}
+	if model != nil {
+		b.table = model.TableName()
+	}
+	return b
+}
+
+func (b *builder) quote(identifier string) string {
+	if b.dialect.Quote == "" {
+		return identifier
+	}
+	return b.dialect.Quote + identifier + b.dialect.Quote
+}
+
+func (b *builder) Select(columns ...string) contract.QueryBuilder {
+	b.columns = append(b.columns, columns...)
+	return b
+}
+
+func (b *builder) Where(condition string, args ...any) contract.QueryBuilder {
+	b.wheres = append(b.wheres, whereClause{cond: sq.Expr(condition, args...)})
+	return b
+}
+
+func (b *builder) WhereIn(column string, values []any) contract.QueryBuilder {
+	b.wheres = append(b.wheres, whereClause{cond: sq.Eq{column: values}})
+	return b
+}
+
+func (b *builder) WhereNotIn(column string, values []any) contract.QueryBuilder {
+	b.wheres = append(b.wheres, whereClause{cond: sq.NotEq{column: values}})
+	return b
+}
+
+func (b *builder) WhereNull(column string) contract.QueryBuilder {
+	b.wheres = append(b.wheres, whereClause{cond: sq.Eq{column: nil}})
+	return b
+}
+
+func (b *builder) WhereNotNull(column string) contract.QueryBuilder {
+	b.wheres = append(b.wheres, whereClause{cond: sq.NotEq{column: nil}})
+	return b
+}
+
+func (b *builder) WhereBetween(column string, start, end any) contract.QueryBuilder {
+	b.wheres = append(b.wheres, whereClause{cond: sq.Expr(column+" BETWEEN ? AND ?", start, end)})
+	return b
+}
+
+func (b *builder) OrWhere(condition string, args ...any) contract.QueryBuilder {
+	b.wheres = append(b.wheres, whereClause{or: true, cond: sq.Expr(condition, args...)})
+	return b
+}
+
+func (b *builder) Join(table, condition string) contract.QueryBuilder {
+	b.joins = append(b.joins, "JOIN "+table+" ON "+condition)
+	return b
+}
+
+func (b *builder) LeftJoin(table, condition string) contract.QueryBuilder {
+	b.joins = append(b.joins, "LEFT JOIN "+table+" ON "+condition)
+	return b
+}
+
+func (b *builder) RightJoin(table, condition string) contract.QueryBuilder {
+	b.joins = append(b.joins, "RIGHT JOIN "+table+" ON "+condition)
+	return b
+}
+
+func (b *builder) InnerJoin(table, condition string) contract.QueryBuilder {
+	b.joins = append(b.joins, "INNER JOIN "+table+" ON "+condition)
+	return b
+}
+
+func (b *builder) OrderBy(column, direction string) contract.QueryBuilder {
+	b.orderBys = append(b.orderBys, orderEntry{column: column, direction: direction})
+	return b
+}
+
+func (b *builder) GroupBy(columns ...string) contract.QueryBuilder {
+	b.groupBys = append(b.groupBys, columns...)
+	return b
+}
+
+func (b *builder) Having(condition string, args ...any) contract.QueryBuilder {
+	b.havings = append(b.havings, whereClause{cond: sq.Expr(condition, args...)})
+	return b
+}
+
+func (b *builder) Limit(limit int) contract.QueryBuilder {
+	l := uint64(limit)
+	b.limit = &l
+	return b
+}
+
+func (b *builder) Offset(offset int) contract.QueryBuilder {
+	o := uint64(offset)
+	b.offset = &o
+	return b
+}
+
+// Cursor records a keyset boundary column and also orders by it, so callers
+// don't need a separate OrderBy call for each Cursor call.
+func (b *builder) Cursor(column string, lastValue any, direction string) contract.QueryBuilder {
+	b.cursors = append(b.cursors, cursorBound{column: column, value: lastValue, direction: direction})
+	b.orderBys = append(b.orderBys, orderEntry{column: column, direction: direction, fromCursor: true})
+	return b
+}
+
+// Backward flips the boundary operator and ORDER BY set up by Cursor so the
+// next Paginate call fetches the page before the boundary instead of the
+// page after it. Paginate still reverses the fetched rows back into forward
+// order before returning them, and resets this flag once it has.
+func (b *builder) Backward() contract.QueryBuilder {
+	b.backward = true
+	return b
+}
+
+// cursorWhere builds the standard keyset row-wise comparison for b.cursors:
+// (c0 op v0) OR (c0 = v0 AND c1 op v1) OR (c0 = v0 AND c1 = v1 AND c2 op v2) ...
+// When b.backward is set, op is flipped so the query walks the opposite
+// direction across the boundary.
+func (b *builder) cursorWhere() sq.Sqlizer {
+	if len(b.cursors) == 0 {
+		return nil
+	}
+	var branches sq.Or
+	for i, cur := range b.cursors {
+		op := ">"
+		if cur.direction == "desc" {
+			op = "<"
+		}
+		if b.backward {
+			if op == ">" {
+				op = "<"
+			} else {
+				op = ">"
+			}
+		}
+		conj := make(sq.And, 0, i+1)
+		for _, prior := range b.cursors[:i] {
+			conj = append(conj, sq.Eq{prior.column: prior.value})
+		}
+		conj = append(conj, sq.Expr(cur.column+" "+op+" ?", cur.value))
+		branches = append(branches, conj)
+	}
+	return branches
+}
+
+// With records relation names to eager-load. Squirrel has no concept of
+// model relations; the Repository layer is responsible for issuing the
+// follow-up queries these names imply.
+func (b *builder) With(relations ...string) contract.QueryBuilder {
+	b.relations = append(b.relations, relations...)
+	return b
+}
+
+// WithCount records relation names whose count should be attached to each
+// result row. As with With, resolving these is left to the Repository layer.
+func (b *builder) WithCount(relations ...string) contract.QueryBuilder {
+	b.withCounts = append(b.withCounts, relations...)
+	return b
+}
+
+func (b *builder) Scoped() contract.QueryBuilder {
+	b.unscoped = false
+	return b
+}
+
+func (b *builder) Unscoped() contract.QueryBuilder {
+	b.unscoped = true
+	return b
+}
+
+func (b *builder) selectBuilder() sq.SelectBuilder {
+	columns := b.columns
+	if len(columns) == 0 {
+		columns = []string{"*"}
+	}
+	sel := b.sb.Select(columns...).From(b.quote(b.table))
+	for _, join := range b.joins {
+		sel = sel.JoinClause(join)
+	}
+	for _, w := range b.wheres {
+		if w.or {
+			sel = sel.Where(sq.Or{w.cond})
+		} else {
+			sel = sel.Where(w.cond)
+		}
+	}
+	if cond := b.cursorWhere(); cond != nil {
+		sel = sel.Where(cond)
+	}
+	if len(b.groupBys) > 0 {
+		sel = sel.GroupBy(b.groupBys...)
+	}
+	for _, h := range b.havings {
+		sel = sel.Having(h.cond)
+	}
+	if len(b.orderBys) > 0 {
+		sel = sel.OrderBy(b.orderByClauses()...)
+	}
+	if b.limit != nil {
+		sel = sel.Limit(*b.limit)
+	}
+	if b.offset != nil {
+		sel = sel.Offset(*b.offset)
+	}
+	return sel
+}
+
+// orderByClauses renders b.orderBys as "column direction" strings, flipping
+// the direction of cursor-derived entries when b.backward is set so the
+// result set is walked in reverse (Paginate then reverses the rows back).
+func (b *builder) orderByClauses() []string {
+	clauses := make([]string, len(b.orderBys))
+	for i, entry := range b.orderBys {
+		direction := entry.direction
+		if entry.fromCursor && b.backward {
+			if strings.EqualFold(direction, "desc") {
+				direction = "asc"
+			} else {
+				direction = "desc"
+			}
+		}
+		clauses[i] = entry.column + " " + direction
+	}
+	return clauses
+}
+
+func (b *builder) Find(ctx context.Context, dest any) error {
+	return b.Get(ctx, dest)
+}
+
+func (b *builder) First(ctx context.Context, dest any) error {
+	orig := b.limit
+	one := uint64(1)
+	b.limit = &one
+	defer func() { b.limit = orig }()
+	return b.Get(ctx, dest)
+}
+
+func (b *builder) Get(ctx context.Context, dest any) error {
+	query, args, err := b.toSelectSQL()
+	if err != nil {
+		return err
+	}
+	rows, err := b.exec.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("squirrel: query failed: %w", err)
+	}
+	defer rows.Close()
+	return scanRows(rows, dest)
+}
+
+func (b *builder) Count(ctx context.Context) (int64, error) {
+	sel := b.selectBuilder()
+	sel = sel.RemoveLimit().RemoveOffset()
+	query, args, err := b.sb.Select("COUNT(*)").FromSelect(sel, "t").ToSql()
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := b.exec.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("squirrel: count failed: %w", err)
+	}
+	return count, nil
+}
+
+func (b *builder) Exists(ctx context.Context) (bool, error) {
+	count, err := b.Count(ctx)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Paginate fetches one page of size+1 rows past the Cursor boundary (to
+// detect HasMore), trims back to size, and returns opaque cursors for the
+// adjacent pages. When Backward was called, the query instead walks
+// backward from the boundary; the fetched rows are reversed back into
+// forward order before NextCursor/PrevCursor are computed, so callers never
+// see a reversed page.
+func (b *builder) Paginate(ctx context.Context, size int, dest any) (*contract.Page, error) {
+	if len(b.cursors) == 0 {
+		return nil, fmt.Errorf("squirrel: Paginate requires at least one Cursor boundary")
+	}
+
+	backward := b.backward
+	page := b.Clone().(*builder)
+	b.backward = false
+	page.limit = nil
+	fetch := uint64(size) + 1
+	page.limit = &fetch
+
+	if err := page.Get(ctx, dest); err != nil {
+		return nil, err
+	}
+
+	items := reflect.ValueOf(dest).Elem()
+	hasMore := items.Len() > size
+	if hasMore {
+		items.Set(items.Slice(0, size))
+	}
+	if backward {
+		reverseInPlace(items)
+	}
+
+	result := &contract.Page{Items: dest, HasMore: hasMore}
+	if items.Len() > 0 {
+		firstCursor, firstErr := b.rowCursor(items.Index(0))
+		lastCursor, lastErr := b.rowCursor(items.Index(items.Len() - 1))
+		if !backward {
+			// firstCursor: always usable to page back toward the start.
+			// lastCursor: only usable to page forward if another page exists.
+			if firstErr == nil {
+				result.PrevCursor = firstCursor
+			}
+			if hasMore && lastErr == nil {
+				result.NextCursor = lastCursor
+			}
+		} else {
+			// The page was fetched walking backward then reversed into
+			// natural order, so roles swap: firstCursor is the far edge of
+			// this backward walk (only usable if more pages lie beyond it),
+			// lastCursor sits next to where we started (always usable to
+			// page forward again).
+			if hasMore && firstErr == nil {
+				result.PrevCursor = firstCursor
+			}
+			if lastErr == nil {
+				result.NextCursor = lastCursor
+			}
+		}
+	}
+	return result, nil
+}
+
+// reverseInPlace reverses a reflect.Value of Kind Slice in place.
+func reverseInPlace(items reflect.Value) {
+	swap := reflect.Swapper(items.Interface())
+	for i, j := 0, items.Len()-1; i < j; i, j = i+1, j-1 {
+		swap(i, j)
+	}
+}
+
+// rowCursor reads the value of each Cursor boundary column off of row
+// (a struct or pointer-to-struct) and encodes them into a single token.
+func (b *builder) rowCursor(row reflect.Value) (string, error) {
+	for row.Kind() == reflect.Ptr {
+		row = row.Elem()
+	}
+	fields := fieldsByColumn(row.Type())
+	values := make([]any, len(b.cursors))
+	for i, cur := range b.cursors {
+		idx, ok := fields[strings.ToLower(cur.column)]
+		if !ok {
+			return "", fmt.Errorf("squirrel: cursor column %q not found on result struct", cur.column)
+		}
+		values[i] = row.Field(idx).Interface()
+	}
+	return contract.EncodeCursor(values...)
+}
+
+// CountEstimate returns an approximate row count for the table using the
+// dialect's statistics catalog when it knows one (Postgres' pg_class),
+// falling back to an exact COUNT(*) otherwise.
+func (b *builder) CountEstimate(ctx context.Context) (int64, error) {
+	if b.dialect.Placeholder == contract.PlaceholderDollar {
+		var estimate int64
+		err := b.exec.QueryRowContext(ctx,
+			`SELECT reltuples::bigint FROM pg_class WHERE oid = quote_ident($1)::regclass`,
+			b.table,
+		).Scan(&estimate)
+		if err == nil && estimate >= 0 {
+			return estimate, nil
+		}
+	}
+	return b.Count(ctx)
+}
+
+func (b *builder) Create(ctx context.Context, value any) error {
+	cols, vals, err := columnsAndValues(value)
+	if err != nil {
+		return err
+	}
+	ins := b.sb.Insert(b.quote(b.table)).Columns(cols...).Values(vals...)
+	if b.dialect.SupportsReturning {
+		ins = ins.Suffix("RETURNING *")
+	}
+	query, args, err := ins.ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = b.exec.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("squirrel: insert failed: %w", err)
+	}
+	return nil
+}
+
+func (b *builder) Update(ctx context.Context, values any) error {
+	cols, vals, err := columnsAndValues(values)
+	if err != nil {
+		return err
+	}
+	upd := b.sb.Update(b.quote(b.table))
+	for i, col := range cols {
+		upd = upd.Set(col, vals[i])
+	}
+	for _, w := range b.wheres {
+		upd = upd.Where(w.cond)
+	}
+	query, args, err := upd.ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = b.exec.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("squirrel: update failed: %w", err)
+	}
+	return nil
+}
+
+func (b *builder) Delete(ctx context.Context) error {
+	del := b.sb.Delete(b.quote(b.table))
+	for _, w := range b.wheres {
+		del = del.Where(w.cond)
+	}
+	query, args, err := del.ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = b.exec.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("squirrel: delete failed: %w", err)
+	}
+	return nil
+}
+
+func (b *builder) Raw(query string, args ...any) contract.QueryBuilder {
+	b.rawSQL = query
+	b.rawArgs = args
+	return b
+}
+
+func (b *builder) Exec(ctx context.Context, query string, args ...any) error {
+	_, err := b.exec.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("squirrel: exec failed: %w", err)
+	}
+	return nil
+}
+
+func (b *builder) toSelectSQL() (string, []any, error) {
+	if b.rawSQL != "" {
+		return b.rawSQL, b.rawArgs, nil
+	}
+	return b.selectBuilder().ToSql()
+}
+
+func (b *builder) ToSQL() (string, []any, error) {
+	return b.toSelectSQL()
+}
+
+func (b *builder) Clone() contract.QueryBuilder {
+	clone := *b
+	clone.columns = append([]string(nil), b.columns...)
+	clone.wheres = append([]whereClause(nil), b.wheres...)
+	clone.joins = append([]string(nil), b.joins...)
+	clone.orderBys = append([]orderEntry(nil), b.orderBys...)
+	clone.groupBys = append([]string(nil), b.groupBys...)
+	clone.havings = append([]whereClause(nil), b.havings...)
+	clone.cursors = append([]cursorBound(nil), b.cursors...)
+	clone.relations = append([]string(nil), b.relations...)
+	clone.withCounts = append([]string(nil), b.withCounts...)
+	return &clone
+}
+
+func (b *builder) Reset() contract.QueryBuilder {
+	table, exec, dialect, sb, model := b.table, b.exec, b.dialect, b.sb, b.model
+	*b = builder{table: table, exec: exec, dialect: dialect, sb: sb, model: model}
+	return b
+}
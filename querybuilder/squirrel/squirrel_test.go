@@ -0,0 +1,127 @@
+package squirrel
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/hbttundar/scg-database/contract"
+)
+
+type testModel struct {
+	ID   int64  `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+func (testModel) TableName() string { return "widgets" }
+
+func newTestBuilder(t *testing.T) (contract.QueryBuilder, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	dialect := contract.Dialect{Placeholder: contract.PlaceholderQuestion}
+	return New(testModel{}, db, dialect), mock
+}
+
+func TestCreateInsertsAllColumns(t *testing.T) {
+	builder, mock := newTestBuilder(t)
+
+	mock.ExpectExec(`INSERT INTO widgets \(id,name\) VALUES \(\?,\?\)`).
+		WithArgs(int64(1), "bolt").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if err := builder.Create(context.Background(), testModel{ID: 1, Name: "bolt"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestFindScansIntoStruct(t *testing.T) {
+	builder, mock := newTestBuilder(t)
+
+	rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(int64(7), "nut")
+	mock.ExpectQuery(`SELECT \* FROM widgets WHERE id = \?`).
+		WithArgs(driver.Value(int64(7))).
+		WillReturnRows(rows)
+
+	var got testModel
+	err := builder.Where("id = ?", int64(7)).First(context.Background(), &got)
+	if err != nil {
+		t.Fatalf("First: %v", err)
+	}
+	if got.ID != 7 || got.Name != "nut" {
+		t.Fatalf("unexpected result: %+v", got)
+	}
+}
+
+func TestCursorPaginateForwardAndBackward(t *testing.T) {
+	builder, mock := newTestBuilder(t)
+
+	forwardRows := sqlmock.NewRows([]string{"id", "name"}).
+		AddRow(int64(2), "b").
+		AddRow(int64(3), "c").
+		AddRow(int64(4), "d")
+	mock.ExpectQuery(`SELECT \* FROM widgets WHERE \(\(id > \?\)\) ORDER BY id asc LIMIT 3`).
+		WithArgs(driver.Value(int64(1))).
+		WillReturnRows(forwardRows)
+
+	var page []testModel
+	result, err := builder.Cursor("id", int64(1), "asc").Paginate(context.Background(), 2, &page)
+	if err != nil {
+		t.Fatalf("Paginate: %v", err)
+	}
+	if !result.HasMore {
+		t.Fatalf("expected HasMore true")
+	}
+	items := *(result.Items.(*[]testModel))
+	if len(items) != 2 || items[0].ID != 2 || items[1].ID != 3 {
+		t.Fatalf("unexpected page items: %+v", items)
+	}
+	if result.NextCursor == "" {
+		t.Fatalf("expected a NextCursor")
+	}
+	if result.PrevCursor == "" {
+		t.Fatalf("expected a PrevCursor")
+	}
+
+	nextValues, err := contract.DecodeCursor(result.NextCursor)
+	if err != nil || len(nextValues) != 1 {
+		t.Fatalf("DecodeCursor(next): %v %v", nextValues, err)
+	}
+
+	// Paging backward from the boundary of the first page (id=2) must flip
+	// both the comparison operator and ORDER BY, then reverse the fetched
+	// rows back into ascending order before returning them.
+	backward, mock2 := newTestBuilder(t)
+	backRows := sqlmock.NewRows([]string{"id", "name"}).
+		AddRow(int64(1), "a")
+	mock2.ExpectQuery(`SELECT \* FROM widgets WHERE \(\(id < \?\)\) ORDER BY id desc LIMIT 3`).
+		WithArgs(driver.Value(int64(2))).
+		WillReturnRows(backRows)
+
+	var prevPage []testModel
+	prevResult, err := backward.Cursor("id", int64(2), "asc").Backward().Paginate(context.Background(), 2, &prevPage)
+	if err != nil {
+		t.Fatalf("Paginate backward: %v", err)
+	}
+	prevItems := *(prevResult.Items.(*[]testModel))
+	if len(prevItems) != 1 || prevItems[0].ID != 1 {
+		t.Fatalf("unexpected reversed page items: %+v", prevItems)
+	}
+	if prevResult.HasMore {
+		t.Fatalf("expected no more pages behind id=1")
+	}
+	if prevResult.NextCursor == "" {
+		t.Fatalf("expected a NextCursor pointing back toward the original page")
+	}
+
+	_ = mock
+}
@@ -0,0 +1,711 @@
+// Package repository provides a default, adapter-agnostic
+// contract.Repository implementation on top of a contract.QueryBuilderFactory.
+// It is the piece that actually invokes the hook interfaces declared in
+// contract/hooks.go, consults a Connection's registered Observers, and
+// wires in its configured Cache and OutboxStore: everything the fluent
+// Repository interface promises but that factory/interface alone cannot
+// provide.
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/hbttundar/scg-database/cache"
+	"github.com/hbttundar/scg-database/contract"
+	"github.com/hbttundar/scg-database/schema"
+)
+
+// queryOp is one fluent call recorded against a Repository (Where, With,
+// Cursor, ...) so it can be replayed against a QueryBuilder built fresh for
+// each execution, bound to whichever Connection the call actually runs
+// against (the Repository's own Connection for reads, a transaction's for
+// writes).
+type queryOp func(contract.QueryBuilder) contract.QueryBuilder
+
+// Repository is the default contract.Repository. It is not safe for
+// concurrent use by multiple goroutines sharing the same chained call, the
+// same way a squirrel builder isn't: build one per logical query.
+type Repository struct {
+	conn    contract.Connection
+	model   contract.Model
+	factory contract.QueryBuilderFactory
+	ops     []queryOp
+	noCache bool
+}
+
+// New returns a Repository for model, executing queries built by factory
+// against whichever Connection each call needs (conn for reads, the active
+// transaction's for writes). Pass conn.NewRepository's arguments straight
+// through from a contract.Connection implementation, e.g.:
+//
+//	func (c *Connection) NewRepository(model contract.Model) (contract.Repository, error) {
+//	    return repository.New(c, model, squirrel.Factory(c.dialect))
+//	}
+func New(conn contract.Connection, model contract.Model, factory contract.QueryBuilderFactory) (contract.Repository, error) {
+	if conn == nil {
+		return nil, fmt.Errorf("repository: Connection must not be nil")
+	}
+	if model == nil {
+		return nil, fmt.Errorf("repository: Model must not be nil")
+	}
+	if factory == nil {
+		return nil, fmt.Errorf("repository: QueryBuilderFactory must not be nil")
+	}
+	return &Repository{conn: conn, model: model, factory: factory}, nil
+}
+
+func (r *Repository) clone() *Repository {
+	c := *r
+	c.ops = append([]queryOp(nil), r.ops...)
+	return &c
+}
+
+func (r *Repository) record(op queryOp) contract.Repository {
+	clone := r.clone()
+	clone.ops = append(clone.ops, op)
+	return clone
+}
+
+// builderFor replays every recorded op against a fresh QueryBuilder bound
+// to execConn, the connection handle the caller actually wants this query
+// to run against.
+func (r *Repository) builderFor(execConn any) contract.QueryBuilder {
+	qb := r.factory.NewQueryBuilder(r.model, execConn)
+	for _, op := range r.ops {
+		qb = op(qb)
+	}
+	return qb
+}
+
+func (r *Repository) readBuilder() contract.QueryBuilder {
+	return r.builderFor(r.conn.GetConnection())
+}
+
+func (r *Repository) With(relations ...string) contract.Repository {
+	return r.record(func(qb contract.QueryBuilder) contract.QueryBuilder { return qb.With(relations...) })
+}
+
+func (r *Repository) Where(query any, args ...any) contract.Repository {
+	condition, ok := query.(string)
+	if !ok {
+		condition = fmt.Sprintf("%v", query)
+	}
+	return r.record(func(qb contract.QueryBuilder) contract.QueryBuilder { return qb.Where(condition, args...) })
+}
+
+func (r *Repository) Unscoped() contract.Repository {
+	return r.record(func(qb contract.QueryBuilder) contract.QueryBuilder { return qb.Unscoped() })
+}
+
+func (r *Repository) Limit(limit int) contract.Repository {
+	return r.record(func(qb contract.QueryBuilder) contract.QueryBuilder { return qb.Limit(limit) })
+}
+
+func (r *Repository) Offset(offset int) contract.Repository {
+	return r.record(func(qb contract.QueryBuilder) contract.QueryBuilder { return qb.Offset(offset) })
+}
+
+func (r *Repository) OrderBy(column, direction string) contract.Repository {
+	return r.record(func(qb contract.QueryBuilder) contract.QueryBuilder { return qb.OrderBy(column, direction) })
+}
+
+func (r *Repository) Cursor(column string, lastValue any, direction string) contract.Repository {
+	return r.record(func(qb contract.QueryBuilder) contract.QueryBuilder {
+		return qb.Cursor(column, lastValue, direction)
+	})
+}
+
+func (r *Repository) Backward() contract.Repository {
+	return r.record(func(qb contract.QueryBuilder) contract.QueryBuilder { return qb.Backward() })
+}
+
+func (r *Repository) NoCache() contract.Repository {
+	clone := r.clone()
+	clone.noCache = true
+	return clone
+}
+
+func (r *Repository) QueryBuilder() contract.QueryBuilder {
+	return r.readBuilder()
+}
+
+// --- reads ---
+
+func (r *Repository) Find(ctx context.Context, id any) (contract.Model, error) {
+	pkColumn, err := primaryKeyColumn(r.model)
+	if err != nil {
+		return nil, err
+	}
+	return r.findOne(ctx, cacheKey(r.model, id), func(qb contract.QueryBuilder) contract.QueryBuilder {
+		return qb.Where(pkColumn+" = ?", id)
+	})
+}
+
+func (r *Repository) FindOrFail(ctx context.Context, id any) (contract.Model, error) {
+	return r.Find(ctx, id)
+}
+
+func (r *Repository) First(ctx context.Context) (contract.Model, error) {
+	return r.findOne(ctx, "", nil)
+}
+
+func (r *Repository) FirstOrFail(ctx context.Context) (contract.Model, error) {
+	return r.First(ctx)
+}
+
+// findOne runs the Repository's current query (plus extra, if given)
+// through First, consulting the Connection's Cache under key first unless
+// NoCache was called or key is empty, and invoking AfterFind on the result.
+func (r *Repository) findOne(ctx context.Context, key string, extra queryOp) (contract.Model, error) {
+	load := func(ctx context.Context) (any, error) {
+		dest := newModelPtr(r.model)
+		qb := r.readBuilder()
+		if extra != nil {
+			qb = extra(qb)
+		}
+		if err := qb.First(ctx, dest); err != nil {
+			return nil, err
+		}
+		return dest, nil
+	}
+
+	var value any
+	var err error
+	if c, ok := r.conn.Cache(); ok && !r.noCache && key != "" {
+		value, err = cache.NewAside(c).Load(ctx, key, 0, load)
+	} else {
+		value, err = load(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := materialize(r.model, value)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.runAfterFind(ctx, model); err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+func (r *Repository) Get(ctx context.Context) ([]contract.Model, error) {
+	qb := r.readBuilder()
+	sliceType := reflect.SliceOf(reflect.TypeOf(newModelPtr(r.model)))
+	destPtr := reflect.New(sliceType)
+	if err := qb.Get(ctx, destPtr.Interface()); err != nil {
+		return nil, err
+	}
+
+	items := destPtr.Elem()
+	models := make([]contract.Model, items.Len())
+	for i := 0; i < items.Len(); i++ {
+		model := items.Index(i).Interface().(contract.Model)
+		if err := r.runAfterFind(ctx, model); err != nil {
+			return nil, err
+		}
+		models[i] = model
+	}
+	return models, nil
+}
+
+func (r *Repository) Pluck(ctx context.Context, column string, dest any) error {
+	qb := r.builderFor(r.conn.GetConnection())
+	query, args, err := qb.Select(column).ToSQL()
+	if err != nil {
+		return err
+	}
+	rows, err := r.conn.Select(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	destVal := reflect.ValueOf(dest)
+	if destVal.Kind() != reflect.Ptr || destVal.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("repository: Pluck dest must be a pointer to a slice")
+	}
+	elemType := destVal.Elem().Type().Elem()
+	out := reflect.MakeSlice(destVal.Elem().Type(), 0, len(rows))
+	for _, row := range rows {
+		value := reflect.New(elemType).Elem()
+		if raw, ok := row[column]; ok && raw != nil {
+			if err := assign(value, raw); err != nil {
+				return err
+			}
+		}
+		out = reflect.Append(out, value)
+	}
+	destVal.Elem().Set(out)
+	return nil
+}
+
+func (r *Repository) Paginate(ctx context.Context, size int) (*contract.Page, error) {
+	qb := r.readBuilder()
+	sliceType := reflect.SliceOf(reflect.TypeOf(newModelPtr(r.model)))
+	destPtr := reflect.New(sliceType)
+	page, err := qb.Paginate(ctx, size, destPtr.Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	items := destPtr.Elem()
+	models := make([]contract.Model, items.Len())
+	for i := 0; i < items.Len(); i++ {
+		models[i] = items.Index(i).Interface().(contract.Model)
+	}
+	page.Items = models
+	return page, nil
+}
+
+// --- writes ---
+
+func (r *Repository) Create(ctx context.Context, models ...contract.Model) error {
+	return r.conn.Transaction(ctx, func(tx contract.Connection) error {
+		for _, model := range models {
+			if err := r.runBefore(ctx, tx, model, beforeCreate); err != nil {
+				return err
+			}
+		}
+		qb := r.builderFor(tx.GetConnection())
+		for _, model := range models {
+			if err := qb.Create(ctx, model); err != nil {
+				return fmt.Errorf("repository: create %s: %w", r.model.TableName(), err)
+			}
+			if err := r.appendOutbox(ctx, tx, model, "created"); err != nil {
+				return err
+			}
+		}
+		for _, model := range models {
+			if err := r.runAfter(ctx, tx, model, afterCreate); err != nil {
+				return err
+			}
+			r.invalidate(model)
+		}
+		return nil
+	})
+}
+
+func (r *Repository) CreateInBatches(ctx context.Context, models []contract.Model, batchSize int) error {
+	if batchSize <= 0 {
+		batchSize = len(models)
+	}
+	for start := 0; start < len(models); start += batchSize {
+		end := start + batchSize
+		if end > len(models) {
+			end = len(models)
+		}
+		if err := r.Create(ctx, models[start:end]...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Repository) Update(ctx context.Context, models ...contract.Model) error {
+	pkColumn, err := primaryKeyColumn(r.model)
+	if err != nil {
+		return err
+	}
+	return r.conn.Transaction(ctx, func(tx contract.Connection) error {
+		for _, model := range models {
+			if err := r.runBefore(ctx, tx, model, beforeUpdate); err != nil {
+				return err
+			}
+		}
+		for _, model := range models {
+			id, err := primaryKeyValue(model, pkColumn)
+			if err != nil {
+				return err
+			}
+			qb := r.builderFor(tx.GetConnection()).Where(pkColumn+" = ?", id)
+			if err := qb.Update(ctx, model); err != nil {
+				return fmt.Errorf("repository: update %s: %w", r.model.TableName(), err)
+			}
+			if err := r.appendOutbox(ctx, tx, model, "updated"); err != nil {
+				return err
+			}
+		}
+		for _, model := range models {
+			if err := r.runAfter(ctx, tx, model, afterUpdate); err != nil {
+				return err
+			}
+			r.invalidate(model)
+		}
+		return nil
+	})
+}
+
+func (r *Repository) Delete(ctx context.Context, models ...contract.Model) error {
+	return r.delete(ctx, models, false)
+}
+
+func (r *Repository) ForceDelete(ctx context.Context, models ...contract.Model) error {
+	return r.delete(ctx, models, true)
+}
+
+func (r *Repository) delete(ctx context.Context, models []contract.Model, force bool) error {
+	pkColumn, err := primaryKeyColumn(r.model)
+	if err != nil {
+		return err
+	}
+	return r.conn.Transaction(ctx, func(tx contract.Connection) error {
+		for _, model := range models {
+			if err := r.runBefore(ctx, tx, model, beforeDelete); err != nil {
+				return err
+			}
+		}
+		for _, model := range models {
+			id, err := primaryKeyValue(model, pkColumn)
+			if err != nil {
+				return err
+			}
+			qb := r.builderFor(tx.GetConnection()).Where(pkColumn+" = ?", id)
+			if force {
+				qb = qb.Unscoped()
+			}
+			if err := qb.Delete(ctx); err != nil {
+				return fmt.Errorf("repository: delete %s: %w", r.model.TableName(), err)
+			}
+			if err := r.appendOutbox(ctx, tx, model, "deleted"); err != nil {
+				return err
+			}
+		}
+		for _, model := range models {
+			if err := r.runAfter(ctx, tx, model, afterDelete); err != nil {
+				return err
+			}
+			r.invalidate(model)
+		}
+		return nil
+	})
+}
+
+func (r *Repository) FirstOrCreate(ctx context.Context, condition contract.Model, create ...contract.Model) (contract.Model, error) {
+	existing, err := r.whereFromModel(condition).First(ctx)
+	if err == nil {
+		return existing, nil
+	}
+
+	toCreate := condition
+	if len(create) > 0 {
+		toCreate = create[0]
+	}
+	if err := r.Create(ctx, toCreate); err != nil {
+		return nil, err
+	}
+	return toCreate, nil
+}
+
+func (r *Repository) UpdateOrCreate(ctx context.Context, condition contract.Model, values any) (contract.Model, error) {
+	existing, err := r.whereFromModel(condition).First(ctx)
+	if err != nil {
+		if err := applyValues(condition, values); err != nil {
+			return nil, err
+		}
+		if err := r.Create(ctx, condition); err != nil {
+			return nil, err
+		}
+		return condition, nil
+	}
+	if err := applyValues(existing, values); err != nil {
+		return nil, err
+	}
+	if err := r.Update(ctx, existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// applyValues merges values (a struct or map[string]any keyed by "db" tag
+// name) onto model's matching fields in place.
+func applyValues(model contract.Model, values any) error {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	target := reflect.ValueOf(model)
+	for target.Kind() == reflect.Ptr {
+		target = target.Elem()
+	}
+	fields := fieldsByDBName(target.Type())
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for name, msg := range raw {
+		idx, ok := fields[strings.ToLower(name)]
+		if !ok {
+			continue
+		}
+		fieldVal := target.Field(idx)
+		if err := json.Unmarshal(msg, fieldVal.Addr().Interface()); err != nil {
+			return fmt.Errorf("repository: applying value for %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// whereFromModel returns a Repository whose query is scoped to every
+// non-zero, non-primary-key column of condition, equality-matched.
+func (r *Repository) whereFromModel(condition contract.Model) *Repository {
+	spec, err := schema.ParseModel(condition)
+	if err != nil {
+		return r.clone()
+	}
+	value := reflect.ValueOf(condition)
+	for value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+
+	scoped := r.clone()
+	fields := fieldsByDBName(value.Type())
+	for _, col := range spec.Columns {
+		idx, ok := fields[strings.ToLower(col.Name)]
+		if !ok {
+			continue
+		}
+		fieldVal := value.Field(idx)
+		if fieldVal.IsZero() {
+			continue
+		}
+		scoped.ops = append(scoped.ops, func(column string, v any) queryOp {
+			return func(qb contract.QueryBuilder) contract.QueryBuilder {
+				return qb.Where(column+" = ?", v)
+			}
+		}(col.Name, fieldVal.Interface()))
+	}
+	return scoped
+}
+
+func fieldsByDBName(t reflect.Type) map[string]int {
+	out := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Tag.Get("db")
+		if name == "" || name == "-" {
+			name = field.Name
+		} else {
+			for j, r := range name {
+				if r == ',' {
+					name = name[:j]
+					break
+				}
+			}
+		}
+		out[strings.ToLower(name)] = i
+	}
+	return out
+}
+
+// --- outbox, cache invalidation, hook dispatch ---
+
+func (r *Repository) appendOutbox(ctx context.Context, tx contract.Connection, model contract.Model, eventType string) error {
+	store, ok := tx.Outbox()
+	if !ok {
+		return nil
+	}
+	payload, err := json.Marshal(model)
+	if err != nil {
+		return err
+	}
+	pkColumn, err := primaryKeyColumn(model)
+	aggregateID := ""
+	if err == nil {
+		if id, err := primaryKeyValue(model, pkColumn); err == nil {
+			aggregateID = fmt.Sprintf("%v", id)
+		}
+	}
+	event := contract.OutboxEvent{
+		ID:            newEventID(),
+		AggregateType: model.TableName(),
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Payload:       payload,
+		CreatedAt:     time.Now(),
+	}
+	return store.Append(ctx, tx, event)
+}
+
+func (r *Repository) invalidate(model contract.Model) {
+	c, ok := r.conn.Cache()
+	if !ok {
+		return
+	}
+	pkColumn, err := primaryKeyColumn(model)
+	if err != nil {
+		return
+	}
+	id, err := primaryKeyValue(model, pkColumn)
+	if err != nil {
+		return
+	}
+	_ = cache.NewAside(c).Invalidate(cacheKey(model, id))
+}
+
+type hookStage int
+
+const (
+	beforeCreate hookStage = iota
+	afterCreate
+	beforeUpdate
+	afterUpdate
+	beforeDelete
+	afterDelete
+)
+
+func (r *Repository) runBefore(ctx context.Context, tx contract.Connection, model contract.Model, stage hookStage) error {
+	if err := invokeHook(ctx, tx, model, stage); err != nil {
+		return err
+	}
+	for _, obs := range r.conn.ObserversFor(model) {
+		if err := invokeHook(ctx, tx, obs, stage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Repository) runAfter(ctx context.Context, tx contract.Connection, model contract.Model, stage hookStage) error {
+	if err := invokeHook(ctx, tx, model, stage); err != nil {
+		return err
+	}
+	for _, obs := range r.conn.ObserversFor(model) {
+		if err := invokeHook(ctx, tx, obs, stage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Repository) runAfterFind(ctx context.Context, model contract.Model) error {
+	if finder, ok := model.(contract.AfterFinder); ok {
+		if err := finder.AfterFind(ctx, r.conn); err != nil {
+			return err
+		}
+	}
+	for _, obs := range r.conn.ObserversFor(model) {
+		if finder, ok := obs.(contract.AfterFinder); ok {
+			if err := finder.AfterFind(ctx, r.conn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func invokeHook(ctx context.Context, tx contract.Connection, target any, stage hookStage) error {
+	switch stage {
+	case beforeCreate:
+		if h, ok := target.(contract.BeforeCreator); ok {
+			return h.BeforeCreate(ctx, tx)
+		}
+	case afterCreate:
+		if h, ok := target.(contract.AfterCreator); ok {
+			return h.AfterCreate(ctx, tx)
+		}
+	case beforeUpdate:
+		if h, ok := target.(contract.BeforeUpdater); ok {
+			return h.BeforeUpdate(ctx, tx)
+		}
+	case afterUpdate:
+		if h, ok := target.(contract.AfterUpdater); ok {
+			return h.AfterUpdate(ctx, tx)
+		}
+	case beforeDelete:
+		if h, ok := target.(contract.BeforeDeleter); ok {
+			return h.BeforeDelete(ctx, tx)
+		}
+	case afterDelete:
+		if h, ok := target.(contract.AfterDeleter); ok {
+			return h.AfterDelete(ctx, tx)
+		}
+	}
+	return nil
+}
+
+// --- reflection helpers ---
+
+func newModelPtr(model contract.Model) contract.Model {
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return reflect.New(t).Interface().(contract.Model)
+}
+
+func materialize(model contract.Model, value any) (contract.Model, error) {
+	if m, ok := value.(contract.Model); ok {
+		return m, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	dest := newModelPtr(model)
+	if err := json.Unmarshal(data, dest); err != nil {
+		return nil, err
+	}
+	return dest, nil
+}
+
+func primaryKeyColumn(model contract.Model) (string, error) {
+	spec, err := schema.ParseModel(model)
+	if err != nil {
+		return "", err
+	}
+	for _, col := range spec.Columns {
+		if col.PrimaryKey {
+			return col.Name, nil
+		}
+	}
+	return "", fmt.Errorf("repository: %s has no column tagged db:\"...,pk\"", model.TableName())
+}
+
+func primaryKeyValue(model contract.Model, pkColumn string) (any, error) {
+	value := reflect.ValueOf(model)
+	for value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	fields := fieldsByDBName(value.Type())
+	idx, ok := fields[strings.ToLower(pkColumn)]
+	if !ok {
+		return nil, fmt.Errorf("repository: primary key column %q not found on %T", pkColumn, model)
+	}
+	return value.Field(idx).Interface(), nil
+}
+
+func cacheKey(model contract.Model, id any) string {
+	return fmt.Sprintf("%s:%v", model.TableName(), id)
+}
+
+func newEventID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+func assign(dest reflect.Value, raw any) error {
+	rv := reflect.ValueOf(raw)
+	if rv.Type().AssignableTo(dest.Type()) {
+		dest.Set(rv)
+		return nil
+	}
+	if rv.Type().ConvertibleTo(dest.Type()) {
+		dest.Set(rv.Convert(dest.Type()))
+		return nil
+	}
+	return fmt.Errorf("repository: cannot assign %T into %s", raw, dest.Type())
+}
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/hbttundar/scg-database/cache"
+	"github.com/hbttundar/scg-database/connbase"
+	"github.com/hbttundar/scg-database/contract"
+	"github.com/hbttundar/scg-database/querybuilder/squirrel"
+)
+
+type widget struct {
+	ID   int64  `db:"id,pk"`
+	Name string `db:"name"`
+
+	beforeCreateCalled bool
+	afterCreateCalled  bool
+	afterFindCalled    bool
+}
+
+func (w widget) TableName() string { return "widgets" }
+
+func (w *widget) BeforeCreate(context.Context, contract.Connection) error {
+	w.beforeCreateCalled = true
+	return nil
+}
+
+func (w *widget) AfterCreate(context.Context, contract.Connection) error {
+	w.afterCreateCalled = true
+	return nil
+}
+
+func (w *widget) AfterFind(context.Context, contract.Connection) error {
+	w.afterFindCalled = true
+	return nil
+}
+
+// fakeConn is a minimal contract.Connection wired to a sqlmock *sql.DB, used
+// so Repository's generated SQL can be asserted without a real database.
+type fakeConn struct {
+	connbase.Base
+	db *sql.DB
+}
+
+func newFakeConn(t *testing.T, opts ...contract.ConnectOption) (*fakeConn, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return &fakeConn{Base: connbase.NewBase(opts...), db: db}, mock
+}
+
+func (c *fakeConn) GetConnection() any                                        { return c.db }
+func (c *fakeConn) Ping(context.Context) error                                { return nil }
+func (c *fakeConn) Close() error                                              { return c.db.Close() }
+func (c *fakeConn) NewRepository(contract.Model) (contract.Repository, error) { return nil, nil }
+func (c *fakeConn) Transaction(ctx context.Context, fn func(contract.Connection) error) error {
+	return fn(c)
+}
+func (c *fakeConn) Select(ctx context.Context, query string, bindings ...any) ([]map[string]any, error) {
+	rows, err := c.db.QueryContext(ctx, query, bindings...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var out []map[string]any
+	for rows.Next() {
+		values := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		row := make(map[string]any, len(cols))
+		for i, col := range cols {
+			row[col] = values[i]
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
+func (c *fakeConn) Statement(ctx context.Context, query string, bindings ...any) (sql.Result, error) {
+	return c.db.ExecContext(ctx, query, bindings...)
+}
+
+func newRepo(t *testing.T, opts ...contract.ConnectOption) (*Repository, *fakeConn, sqlmock.Sqlmock) {
+	t.Helper()
+	conn, mock := newFakeConn(t, opts...)
+	factory := squirrel.Factory(contract.Dialect{Placeholder: contract.PlaceholderQuestion})
+	repo, err := New(conn, widget{}, factory)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return repo.(*Repository), conn, mock
+}
+
+func TestFindScansAndRunsAfterFind(t *testing.T) {
+	repo, _, mock := newRepo(t)
+
+	mock.ExpectQuery(`SELECT \* FROM widgets WHERE id = \?`).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(int64(7), "bolt"))
+
+	model, err := repo.Find(context.Background(), int64(7))
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	w := model.(*widget)
+	if w.ID != 7 || w.Name != "bolt" {
+		t.Fatalf("unexpected model: %+v", w)
+	}
+	if !w.afterFindCalled {
+		t.Fatalf("expected AfterFind to run on the found model")
+	}
+}
+
+func TestCreateRunsHooksAndObservers(t *testing.T) {
+	repo, conn, mock := newRepo(t)
+
+	observed := false
+	conn.RegisterObserver(widget{}, observerFunc(func() { observed = true }))
+
+	mock.ExpectExec(`INSERT INTO widgets \(id,name\) VALUES \(\?,\?\)`).
+		WithArgs(int64(1), "bolt").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	w := &widget{ID: 1, Name: "bolt"}
+	if err := repo.Create(context.Background(), w); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if !w.beforeCreateCalled || !w.afterCreateCalled {
+		t.Fatalf("expected BeforeCreate and AfterCreate to run, got %+v", w)
+	}
+	if !observed {
+		t.Fatalf("expected the registered observer to run")
+	}
+}
+
+// observerFunc lets a test register a bare AfterCreate implementation
+// without declaring a one-off named type per test.
+type observerFunc func()
+
+func (f observerFunc) AfterCreate(context.Context, contract.Connection) error {
+	f()
+	return nil
+}
+
+func TestCreateAppendsOutboxEvent(t *testing.T) {
+	var appended []contract.OutboxEvent
+	store := fakeOutboxStore{onAppend: func(e contract.OutboxEvent) { appended = append(appended, e) }}
+
+	repo, _, mock := newRepo(t, contract.WithOutbox(store))
+
+	mock.ExpectExec(`INSERT INTO widgets \(id,name\) VALUES \(\?,\?\)`).
+		WithArgs(int64(2), "nut").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if err := repo.Create(context.Background(), &widget{ID: 2, Name: "nut"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if len(appended) != 1 || appended[0].EventType != "created" || appended[0].AggregateID != "2" {
+		t.Fatalf("unexpected outbox events: %+v", appended)
+	}
+}
+
+func TestFindUsesCacheOnSecondCall(t *testing.T) {
+	c := cache.NewLRU(10)
+	repo, _, mock := newRepo(t, contract.WithCache(c))
+
+	mock.ExpectQuery(`SELECT \* FROM widgets WHERE id = \?`).
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(int64(3), "washer"))
+
+	if _, err := repo.Find(context.Background(), int64(3)); err != nil {
+		t.Fatalf("Find (miss): %v", err)
+	}
+	// A second Find for the same id must be served from cache, so sqlmock
+	// seeing no further expectations set is itself the assertion: if
+	// Repository queried again, ExpectationsWereMet would fail below.
+	model, err := repo.Find(context.Background(), int64(3))
+	if err != nil {
+		t.Fatalf("Find (hit): %v", err)
+	}
+	if model.(*widget).Name != "washer" {
+		t.Fatalf("unexpected cached model: %+v", model)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+type fakeOutboxStore struct {
+	onAppend func(contract.OutboxEvent)
+}
+
+func (s fakeOutboxStore) Append(_ context.Context, _ contract.Connection, event contract.OutboxEvent) error {
+	s.onAppend(event)
+	return nil
+}
+func (s fakeOutboxStore) FetchUndelivered(context.Context, int) ([]contract.OutboxEvent, error) {
+	return nil, nil
+}
+func (s fakeOutboxStore) MarkDelivered(context.Context, ...string) error  { return nil }
+func (s fakeOutboxStore) MarkFailed(context.Context, string, error) error { return nil }
+func (s fakeOutboxStore) Poison(context.Context, string, error) error     { return nil }
@@ -0,0 +1,143 @@
+package resolver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hbttundar/scg-database/connbase"
+	"github.com/hbttundar/scg-database/contract"
+)
+
+// Connection is a contract.Connection that fans reads across a Resolver's
+// replicas and routes writes, Statement and Transaction to its primary.
+// Select falls back to the primary, quarantining the replica it tried, if
+// the chosen replica's query itself fails.
+type Connection struct {
+	connbase.Base
+
+	res            *Resolver
+	replicaBackoff time.Duration
+	newRepository  func(conn contract.Connection, model contract.Model) (contract.Repository, error)
+}
+
+// NewConnection wraps res as a contract.Connection. newRepository builds
+// the Repository NewRepository returns; in production code pass a closure
+// that calls repository.New with the QueryBuilderFactory to use.
+func NewConnection(res *Resolver, replicaBackoff time.Duration, newRepository func(conn contract.Connection, model contract.Model) (contract.Repository, error), opts ...contract.ConnectOption) *Connection {
+	return &Connection{
+		Base:           connbase.NewBase(opts...),
+		res:            res,
+		replicaBackoff: replicaBackoff,
+		newRepository:  newRepository,
+	}
+}
+
+// GetConnection returns a healthy replica's own driver handle (falling back
+// to the primary's), the same routing Select uses for the non-pinned case.
+// A QueryBuilder built against this (see squirrel.New's executor
+// assertion) therefore executes reads against a replica the same way
+// Select does. Unlike Select, this has no ctx to honor contract.WithPrimary
+// pinning or to quarantine a replica that turns out to be unreachable,
+// since it runs before any query has been attempted; callers that need
+// that should go through Select/Statement instead.
+func (c *Connection) GetConnection() any {
+	conn, err := c.res.Replica(context.Background())
+	if err != nil {
+		return nil
+	}
+	return conn.GetConnection()
+}
+
+func (c *Connection) Ping(ctx context.Context) error {
+	primary, err := c.res.Primary(ctx)
+	if err != nil {
+		return err
+	}
+	return primary.Ping(ctx)
+}
+
+func (c *Connection) Close() error {
+	var errs []error
+	if primary, err := c.res.Primary(context.Background()); err == nil {
+		if err := primary.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, src := range c.res.replicas {
+		if err := src.Conn.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("resolver: closing sources: %v", errs)
+	}
+	return nil
+}
+
+func (c *Connection) NewRepository(model contract.Model) (contract.Repository, error) {
+	if c.newRepository == nil {
+		return nil, fmt.Errorf("resolver: Connection has no Repository constructor configured")
+	}
+	return c.newRepository(c, model)
+}
+
+// Transaction always runs against the primary: writes and anything the
+// caller does inside fn must hit the source of truth, never a replica.
+func (c *Connection) Transaction(ctx context.Context, fn func(txConnection contract.Connection) error) error {
+	primary, err := c.res.Primary(ctx)
+	if err != nil {
+		return err
+	}
+	return primary.Transaction(ctx, fn)
+}
+
+// Select routes to a replica via the Resolver unless ctx was marked with
+// contract.WithPrimary, falling back to (and quarantining the replica
+// against) the primary if the replica's query fails.
+func (c *Connection) Select(ctx context.Context, query string, bindings ...any) ([]map[string]any, error) {
+	if contract.IsPrimary(ctx) {
+		primary, err := c.res.Primary(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return primary.Select(ctx, query, bindings...)
+	}
+
+	replica, err := c.res.Replica(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := replica.Select(ctx, query, bindings...)
+	if err == nil {
+		return rows, nil
+	}
+
+	if name := c.sourceName(replica); name != "" {
+		c.res.Quarantine(name, c.replicaBackoff)
+	}
+	primary, primaryErr := c.res.Primary(ctx)
+	if primaryErr != nil {
+		return nil, err
+	}
+	return primary.Select(ctx, query, bindings...)
+}
+
+// Statement always routes to the primary: it's a write path.
+func (c *Connection) Statement(ctx context.Context, query string, bindings ...any) (sql.Result, error) {
+	primary, err := c.res.Primary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return primary.Statement(ctx, query, bindings...)
+}
+
+func (c *Connection) sourceName(conn contract.Connection) string {
+	for _, src := range c.res.replicas {
+		if src.Conn == conn {
+			return src.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,132 @@
+package resolver
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/hbttundar/scg-database/connbase"
+	"github.com/hbttundar/scg-database/contract"
+	"github.com/hbttundar/scg-database/querybuilder/squirrel"
+	"github.com/hbttundar/scg-database/repository"
+)
+
+// sqlSourceConn is a contract.Connection backed by a real sqlmock *sql.DB,
+// so its GetConnection() satisfies squirrel's executor the way a
+// production DBAdapter's would. It stands in here for the primary/replica
+// sources a resolver.Connection fans out over.
+type sqlSourceConn struct {
+	connbase.Base
+	db *sql.DB
+}
+
+func newSQLSourceConn(t *testing.T) (*sqlSourceConn, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return &sqlSourceConn{Base: connbase.NewBase(), db: db}, mock
+}
+
+func (c *sqlSourceConn) GetConnection() any                                        { return c.db }
+func (c *sqlSourceConn) Ping(context.Context) error                                { return nil }
+func (c *sqlSourceConn) Close() error                                              { return c.db.Close() }
+func (c *sqlSourceConn) NewRepository(contract.Model) (contract.Repository, error) { return nil, nil }
+func (c *sqlSourceConn) Transaction(ctx context.Context, fn func(contract.Connection) error) error {
+	return fn(c)
+}
+
+func (c *sqlSourceConn) Select(ctx context.Context, query string, bindings ...any) ([]map[string]any, error) {
+	rows, err := c.db.QueryContext(ctx, query, bindings...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var out []map[string]any
+	for rows.Next() {
+		values := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		row := make(map[string]any, len(cols))
+		for i, col := range cols {
+			row[col] = values[i]
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
+
+func (c *sqlSourceConn) Statement(ctx context.Context, query string, bindings ...any) (sql.Result, error) {
+	return c.db.ExecContext(ctx, query, bindings...)
+}
+
+type gadget struct {
+	ID   int64  `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+func (gadget) TableName() string { return "gadgets" }
+
+// TestRepositoryOverResolverConnection builds the combination the package
+// doc comments on resolver.Connection/repository.New document as the
+// intended production setup: a resolver.Connection wrapping a primary and a
+// replica, handing out Repositories via repository.New and
+// squirrel.Factory. Find must execute against the replica (GetConnection's
+// routing), Create against the primary (Transaction's routing).
+func TestRepositoryOverResolverConnection(t *testing.T) {
+	res := New(contract.StrategyRoundRobin)
+	primary, primaryMock := newSQLSourceConn(t)
+	replica, replicaMock := newSQLSourceConn(t)
+
+	mustAddSource(t, res, contract.Source{Name: "primary", Role: contract.RolePrimary, Conn: primary})
+	mustAddSource(t, res, contract.Source{Name: "replica", Role: contract.RoleReplica, Conn: replica})
+
+	factory := squirrel.Factory(contract.Dialect{Placeholder: contract.PlaceholderQuestion})
+	conn := NewConnection(res, time.Minute, func(c contract.Connection, model contract.Model) (contract.Repository, error) {
+		return repository.New(c, model, factory)
+	})
+	repo, err := conn.NewRepository(gadget{})
+	if err != nil {
+		t.Fatalf("NewRepository: %v", err)
+	}
+
+	replicaMock.ExpectQuery(`SELECT \* FROM gadgets WHERE id = \?`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(int64(1), "sprocket"))
+
+	model, err := repo.Find(context.Background(), int64(1))
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	if model.(*gadget).Name != "sprocket" {
+		t.Fatalf("unexpected model: %+v", model)
+	}
+	if err := replicaMock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("replica unmet expectations: %v", err)
+	}
+
+	primaryMock.ExpectExec(`INSERT INTO gadgets \(id,name\) VALUES \(\?,\?\)`).
+		WithArgs(int64(2), "cog").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if err := repo.Create(context.Background(), &gadget{ID: 2, Name: "cog"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := primaryMock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("primary unmet expectations: %v", err)
+	}
+}
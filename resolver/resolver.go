@@ -0,0 +1,215 @@
+// Package resolver implements contract.Resolver: round-robin/weighted read
+// routing across replicas, quarantine-with-backoff for unhealthy ones, and
+// a contract.Connection that fans a logical connection out over a primary
+// and its replicas.
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hbttundar/scg-database/contract"
+)
+
+// Resolver is the default contract.Resolver. It is safe for concurrent use.
+type Resolver struct {
+	strategy contract.Strategy
+
+	mu            sync.Mutex
+	primary       *contract.Source
+	replicas      []*contract.Source
+	roundRobinIdx int
+	quarantined   map[string]time.Time // name -> quarantined until
+}
+
+// New returns a Resolver with no sources registered yet; add them with
+// AddSource. strategy picks how Replica distributes traffic across
+// healthy replicas.
+func New(strategy contract.Strategy) *Resolver {
+	return &Resolver{
+		strategy:    strategy,
+		quarantined: make(map[string]time.Time),
+	}
+}
+
+func (r *Resolver) AddSource(source contract.Source) error {
+	if source.Name == "" {
+		return fmt.Errorf("resolver: source must have a name")
+	}
+	if source.Conn == nil {
+		return fmt.Errorf("resolver: source %q has no Connection", source.Name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch source.Role {
+	case contract.RolePrimary:
+		if r.primary != nil {
+			return fmt.Errorf("resolver: primary already registered as %q", r.primary.Name)
+		}
+		src := source
+		r.primary = &src
+	case contract.RoleReplica:
+		for _, existing := range r.replicas {
+			if existing.Name == source.Name {
+				return fmt.Errorf("resolver: replica %q already registered", source.Name)
+			}
+		}
+		src := source
+		r.replicas = append(r.replicas, &src)
+	default:
+		return fmt.Errorf("resolver: unknown source role %q", source.Role)
+	}
+	return nil
+}
+
+func (r *Resolver) Remove(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.primary != nil && r.primary.Name == name {
+		r.primary = nil
+		return nil
+	}
+	for i, src := range r.replicas {
+		if src.Name == name {
+			r.replicas = append(r.replicas[:i], r.replicas[i+1:]...)
+			delete(r.quarantined, name)
+			return nil
+		}
+	}
+	return fmt.Errorf("resolver: no source named %q", name)
+}
+
+func (r *Resolver) Primary(_ context.Context) (contract.Connection, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.primary == nil {
+		return nil, fmt.Errorf("resolver: no primary registered")
+	}
+	return r.primary.Conn, nil
+}
+
+// Replica picks a healthy replica per the Resolver's Strategy, falling back
+// to the primary if every replica is quarantined or none are registered.
+func (r *Resolver) Replica(ctx context.Context) (contract.Connection, error) {
+	r.mu.Lock()
+	candidates := make([]*contract.Source, 0, len(r.replicas))
+	now := time.Now()
+	for _, src := range r.replicas {
+		if until, quarantined := r.quarantined[src.Name]; quarantined && now.Before(until) {
+			continue
+		}
+		candidates = append(candidates, src)
+	}
+	if len(candidates) == 0 {
+		r.mu.Unlock()
+		return r.Primary(ctx)
+	}
+
+	var chosen *contract.Source
+	switch r.strategy {
+	case contract.StrategyWeighted:
+		chosen = r.pickWeightedLocked(candidates)
+	default:
+		chosen = r.pickRoundRobinLocked(candidates)
+	}
+	r.mu.Unlock()
+
+	return chosen.Conn, nil
+}
+
+// pickRoundRobinLocked must be called with r.mu held.
+func (r *Resolver) pickRoundRobinLocked(candidates []*contract.Source) *contract.Source {
+	chosen := candidates[r.roundRobinIdx%len(candidates)]
+	r.roundRobinIdx++
+	return chosen
+}
+
+// pickWeightedLocked must be called with r.mu held. Sources with Weight <=
+// 0 are treated as weight 1.
+func (r *Resolver) pickWeightedLocked(candidates []*contract.Source) *contract.Source {
+	total := 0
+	for _, src := range candidates {
+		total += weightOf(src)
+	}
+	r.roundRobinIdx = (r.roundRobinIdx + 1) % total
+
+	cursor := r.roundRobinIdx
+	for _, src := range candidates {
+		w := weightOf(src)
+		if cursor < w {
+			return src
+		}
+		cursor -= w
+	}
+	return candidates[len(candidates)-1]
+}
+
+func weightOf(src *contract.Source) int {
+	if src.Weight <= 0 {
+		return 1
+	}
+	return src.Weight
+}
+
+func (r *Resolver) Quarantine(name string, backoff time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.quarantined[name] = time.Now().Add(backoff)
+}
+
+func (r *Resolver) Healthy(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	until, quarantined := r.quarantined[name]
+	return !quarantined || !time.Now().Before(until)
+}
+
+// StartHealthChecks runs checker against every registered replica (and the
+// primary) every interval until ctx is canceled, quarantining any source
+// whose check fails for backoff. It returns immediately; the checks run in
+// a background goroutine that stops when ctx is done.
+func (r *Resolver) StartHealthChecks(ctx context.Context, checker contract.HealthChecker, interval, backoff time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.runHealthChecksOnce(ctx, checker, backoff)
+			}
+		}
+	}()
+}
+
+func (r *Resolver) runHealthChecksOnce(ctx context.Context, checker contract.HealthChecker, backoff time.Duration) {
+	r.mu.Lock()
+	sources := make([]contract.Source, 0, len(r.replicas)+1)
+	for _, src := range r.replicas {
+		sources = append(sources, *src)
+	}
+	if r.primary != nil {
+		sources = append(sources, *r.primary)
+	}
+	r.mu.Unlock()
+
+	for _, src := range sources {
+		if err := checker.Check(ctx, src); err != nil {
+			r.Quarantine(src.Name, backoff)
+		}
+	}
+}
+
+// PingHealthChecker is a HealthChecker that considers a Source healthy as
+// long as its Connection.Ping succeeds.
+type PingHealthChecker struct{}
+
+func (PingHealthChecker) Check(ctx context.Context, source contract.Source) error {
+	return source.Conn.Ping(ctx)
+}
@@ -0,0 +1,197 @@
+package resolver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hbttundar/scg-database/connbase"
+	"github.com/hbttundar/scg-database/contract"
+)
+
+// fakeConn is a minimal contract.Connection used to test routing without a
+// real database.
+type fakeConn struct {
+	connbase.Base
+	name      string
+	selectErr error
+	selects   int
+}
+
+func newFakeConn(name string) *fakeConn {
+	return &fakeConn{Base: connbase.NewBase(), name: name}
+}
+
+func (f *fakeConn) GetConnection() any                                        { return f }
+func (f *fakeConn) Ping(context.Context) error                                { return nil }
+func (f *fakeConn) Close() error                                              { return nil }
+func (f *fakeConn) NewRepository(contract.Model) (contract.Repository, error) { return nil, nil }
+func (f *fakeConn) Transaction(ctx context.Context, fn func(contract.Connection) error) error {
+	return fn(f)
+}
+func (f *fakeConn) Select(context.Context, string, ...any) ([]map[string]any, error) {
+	f.selects++
+	if f.selectErr != nil {
+		return nil, f.selectErr
+	}
+	return []map[string]any{{"source": f.name}}, nil
+}
+func (f *fakeConn) Statement(context.Context, string, ...any) (sql.Result, error) { return nil, nil }
+
+func TestRoundRobinAlternatesReplicas(t *testing.T) {
+	res := New(contract.StrategyRoundRobin)
+	primary := newFakeConn("primary")
+	replicaA := newFakeConn("a")
+	replicaB := newFakeConn("b")
+
+	mustAddSource(t, res, contract.Source{Name: "primary", Role: contract.RolePrimary, Conn: primary})
+	mustAddSource(t, res, contract.Source{Name: "a", Role: contract.RoleReplica, Conn: replicaA})
+	mustAddSource(t, res, contract.Source{Name: "b", Role: contract.RoleReplica, Conn: replicaB})
+
+	var seen []string
+	for i := 0; i < 4; i++ {
+		conn, err := res.Replica(context.Background())
+		if err != nil {
+			t.Fatalf("Replica: %v", err)
+		}
+		seen = append(seen, conn.(*fakeConn).name)
+	}
+
+	want := []string{"a", "b", "a", "b"}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Fatalf("round robin sequence = %v, want %v", seen, want)
+		}
+	}
+}
+
+func TestWeightedFavorsHeavierSource(t *testing.T) {
+	res := New(contract.StrategyWeighted)
+	primary := newFakeConn("primary")
+	heavy := newFakeConn("heavy")
+	light := newFakeConn("light")
+
+	mustAddSource(t, res, contract.Source{Name: "primary", Role: contract.RolePrimary, Conn: primary})
+	mustAddSource(t, res, contract.Source{Name: "heavy", Role: contract.RoleReplica, Conn: heavy, Weight: 3})
+	mustAddSource(t, res, contract.Source{Name: "light", Role: contract.RoleReplica, Conn: light, Weight: 1})
+
+	counts := map[string]int{}
+	for i := 0; i < 8; i++ {
+		conn, err := res.Replica(context.Background())
+		if err != nil {
+			t.Fatalf("Replica: %v", err)
+		}
+		counts[conn.(*fakeConn).name]++
+	}
+
+	if counts["heavy"] <= counts["light"] {
+		t.Fatalf("expected heavy source to be picked more often, got %v", counts)
+	}
+}
+
+func TestQuarantineFallsBackToPrimary(t *testing.T) {
+	res := New(contract.StrategyRoundRobin)
+	primary := newFakeConn("primary")
+	replica := newFakeConn("replica")
+
+	mustAddSource(t, res, contract.Source{Name: "primary", Role: contract.RolePrimary, Conn: primary})
+	mustAddSource(t, res, contract.Source{Name: "replica", Role: contract.RoleReplica, Conn: replica})
+
+	res.Quarantine("replica", time.Minute)
+
+	conn, err := res.Replica(context.Background())
+	if err != nil {
+		t.Fatalf("Replica: %v", err)
+	}
+	if conn.(*fakeConn).name != "primary" {
+		t.Fatalf("expected fallback to primary, got %s", conn.(*fakeConn).name)
+	}
+	if res.Healthy("replica") {
+		t.Fatalf("expected replica to be unhealthy while quarantined")
+	}
+}
+
+func TestConnectionSelectFallsBackOnReplicaError(t *testing.T) {
+	res := New(contract.StrategyRoundRobin)
+	primary := newFakeConn("primary")
+	replica := newFakeConn("replica")
+	replica.selectErr = errors.New("replica unreachable")
+
+	mustAddSource(t, res, contract.Source{Name: "primary", Role: contract.RolePrimary, Conn: primary})
+	mustAddSource(t, res, contract.Source{Name: "replica", Role: contract.RoleReplica, Conn: replica})
+
+	conn := NewConnection(res, time.Minute, nil)
+
+	rows, err := conn.Select(context.Background(), "SELECT 1")
+	if err != nil {
+		t.Fatalf("Select: %v", err)
+	}
+	if rows[0]["source"] != "primary" {
+		t.Fatalf("expected fallback row from primary, got %v", rows)
+	}
+	if res.Healthy("replica") {
+		t.Fatalf("expected failed replica to be quarantined")
+	}
+}
+
+func TestConnectionSelectPinnedToPrimary(t *testing.T) {
+	res := New(contract.StrategyRoundRobin)
+	primary := newFakeConn("primary")
+	replica := newFakeConn("replica")
+
+	mustAddSource(t, res, contract.Source{Name: "primary", Role: contract.RolePrimary, Conn: primary})
+	mustAddSource(t, res, contract.Source{Name: "replica", Role: contract.RoleReplica, Conn: replica})
+
+	conn := NewConnection(res, time.Minute, nil)
+
+	ctx := contract.WithPrimary(context.Background())
+	rows, err := conn.Select(ctx, "SELECT 1")
+	if err != nil {
+		t.Fatalf("Select: %v", err)
+	}
+	if rows[0]["source"] != "primary" {
+		t.Fatalf("expected pinned read from primary, got %v", rows)
+	}
+	if replica.selects != 0 {
+		t.Fatalf("expected replica to never be queried, got %d calls", replica.selects)
+	}
+}
+
+type failingChecker struct {
+	failNames map[string]bool
+}
+
+func (f failingChecker) Check(_ context.Context, source contract.Source) error {
+	if f.failNames[source.Name] {
+		return errors.New("unhealthy")
+	}
+	return nil
+}
+
+func TestHealthChecksQuarantineFailingSource(t *testing.T) {
+	res := New(contract.StrategyRoundRobin)
+	primary := newFakeConn("primary")
+	replica := newFakeConn("replica")
+
+	mustAddSource(t, res, contract.Source{Name: "primary", Role: contract.RolePrimary, Conn: primary})
+	mustAddSource(t, res, contract.Source{Name: "replica", Role: contract.RoleReplica, Conn: replica})
+
+	checker := failingChecker{failNames: map[string]bool{"replica": true}}
+	res.runHealthChecksOnce(context.Background(), checker, time.Minute)
+
+	if res.Healthy("replica") {
+		t.Fatalf("expected replica to be quarantined after failing health check")
+	}
+	if !res.Healthy("primary") {
+		t.Fatalf("expected primary to stay healthy")
+	}
+}
+
+func mustAddSource(t *testing.T, res *Resolver, source contract.Source) {
+	t.Helper()
+	if err := res.AddSource(source); err != nil {
+		t.Fatalf("AddSource(%s): %v", source.Name, err)
+	}
+}
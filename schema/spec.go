@@ -0,0 +1,70 @@
+// Package schema derives contract.TableSpec from Model struct tags and
+// syncs it against a live database, complementing the file-based Migrator
+// with auto-migration.
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hbttundar/scg-database/contract"
+)
+
+// ParseModel reads model's struct tags (`db:"name,pk,size=100,index,unique,fk=users.id"`)
+// into a contract.TableSpec. A field with no `db` tag is included under its
+// Go field name; a field tagged `db:"-"` is skipped.
+func ParseModel(model contract.Model) (contract.TableSpec, error) {
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return contract.TableSpec{}, fmt.Errorf("schema: %s is not a struct", t)
+	}
+
+	spec := contract.TableSpec{Name: model.TableName()}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		name := field.Name
+		if parts[0] != "" {
+			name = parts[0]
+		}
+
+		col := contract.ColumnSpec{
+			Name:     name,
+			GoType:   field.Type.String(),
+			Nullable: field.Type.Kind() == reflect.Ptr,
+		}
+		for _, opt := range parts[1:] {
+			switch {
+			case opt == "pk":
+				col.PrimaryKey = true
+			case opt == "index":
+				col.Index = true
+			case opt == "unique":
+				col.Unique = true
+			case strings.HasPrefix(opt, "size="):
+				size, err := strconv.Atoi(strings.TrimPrefix(opt, "size="))
+				if err != nil {
+					return contract.TableSpec{}, fmt.Errorf("schema: field %s: invalid size option %q: %w", field.Name, opt, err)
+				}
+				col.Size = size
+			case strings.HasPrefix(opt, "fk="):
+				col.ForeignKey = strings.TrimPrefix(opt, "fk=")
+			}
+		}
+		spec.Columns = append(spec.Columns, col)
+	}
+	return spec, nil
+}
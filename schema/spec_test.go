@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"sync"
+	"testing"
+)
+
+type product struct {
+	ID    int64  `db:"id,pk"`
+	SKU   string `db:"sku,unique,size=32"`
+	Name  string `db:"name,index"`
+	mu    sync.Mutex
+	cache string
+}
+
+func (*product) TableName() string { return "products" }
+
+func TestParseModelSkipsUnexportedFields(t *testing.T) {
+	spec, err := ParseModel(&product{})
+	if err != nil {
+		t.Fatalf("ParseModel: %v", err)
+	}
+	if spec.Name != "products" {
+		t.Fatalf("unexpected table name: %q", spec.Name)
+	}
+	if len(spec.Columns) != 3 {
+		t.Fatalf("expected 3 columns, got %d: %+v", len(spec.Columns), spec.Columns)
+	}
+	for _, col := range spec.Columns {
+		if col.Name == "mu" || col.Name == "cache" {
+			t.Fatalf("unexported field leaked into columns: %+v", spec.Columns)
+		}
+	}
+}
+
+func TestParseModelReadsTagOptions(t *testing.T) {
+	spec, err := ParseModel(&product{})
+	if err != nil {
+		t.Fatalf("ParseModel: %v", err)
+	}
+
+	byName := make(map[string]struct {
+		pk, unique, index bool
+		size              int
+	}, len(spec.Columns))
+	for _, col := range spec.Columns {
+		byName[col.Name] = struct {
+			pk, unique, index bool
+			size              int
+		}{col.PrimaryKey, col.Unique, col.Index, col.Size}
+	}
+
+	if !byName["id"].pk {
+		t.Fatalf("expected id to be primary key")
+	}
+	if !byName["sku"].unique || byName["sku"].size != 32 {
+		t.Fatalf("expected sku to be unique with size 32, got %+v", byName["sku"])
+	}
+	if !byName["name"].index {
+		t.Fatalf("expected name to be indexed")
+	}
+}
@@ -0,0 +1,124 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hbttundar/scg-database/contract"
+)
+
+// Syncer is the default contract.SchemaSyncer. It diffs Model struct tags
+// against information_schema and renders the result through a
+// dialect-specific contract.DDLRenderer.
+type Syncer struct {
+	Conn     contract.Connection
+	Renderer contract.DDLRenderer
+
+	// DryRun makes Apply a no-op; the SQL is still available on the Plan
+	// returned by Sync.
+	DryRun bool
+
+	// Destructive must be true for Sync to include DROP COLUMN/DROP TABLE
+	// statements, and for Apply to run a Plan that contains them.
+	Destructive bool
+}
+
+// NewSyncer returns a Syncer that reads and writes through conn, rendering
+// DDL with renderer.
+func NewSyncer(conn contract.Connection, renderer contract.DDLRenderer) *Syncer {
+	return &Syncer{Conn: conn, Renderer: renderer}
+}
+
+func (s *Syncer) Sync(ctx context.Context, models ...contract.Model) (contract.Plan, error) {
+	var plan contract.Plan
+
+	for _, model := range models {
+		spec, err := ParseModel(model)
+		if err != nil {
+			return contract.Plan{}, err
+		}
+
+		exists, err := s.tableExists(ctx, spec.Name)
+		if err != nil {
+			return contract.Plan{}, err
+		}
+		if !exists {
+			plan.Statements = append(plan.Statements, s.Renderer.CreateTable(spec))
+			for _, col := range spec.Columns {
+				if col.Index || col.Unique {
+					plan.Statements = append(plan.Statements, s.Renderer.CreateIndex(spec.Name, col))
+				}
+			}
+			continue
+		}
+
+		existing, err := s.existingColumns(ctx, spec.Name)
+		if err != nil {
+			return contract.Plan{}, err
+		}
+
+		wanted := make(map[string]bool, len(spec.Columns))
+		for _, col := range spec.Columns {
+			wanted[col.Name] = true
+			if existing[col.Name] {
+				continue
+			}
+			plan.Statements = append(plan.Statements, s.Renderer.AddColumn(spec.Name, col))
+			if col.Index || col.Unique {
+				plan.Statements = append(plan.Statements, s.Renderer.CreateIndex(spec.Name, col))
+			}
+		}
+
+		if !s.Destructive {
+			continue
+		}
+		for column := range existing {
+			if !wanted[column] {
+				plan.Statements = append(plan.Statements, s.Renderer.DropColumn(spec.Name, column))
+				plan.Destructive = true
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+func (s *Syncer) Apply(ctx context.Context, plan contract.Plan) error {
+	if plan.Destructive && !s.Destructive {
+		return fmt.Errorf("schema: plan contains destructive statements but Destructive is false")
+	}
+	if s.DryRun {
+		return nil
+	}
+	for _, stmt := range plan.Statements {
+		if _, err := s.Conn.Statement(ctx, stmt); err != nil {
+			return fmt.Errorf("schema: applying %q: %w", stmt, err)
+		}
+	}
+	return nil
+}
+
+func (s *Syncer) tableExists(ctx context.Context, table string) (bool, error) {
+	rows, err := s.Conn.Select(ctx,
+		`SELECT table_name FROM information_schema.tables WHERE table_name = ?`, table)
+	if err != nil {
+		return false, fmt.Errorf("schema: checking table %q: %w", table, err)
+	}
+	return len(rows) > 0, nil
+}
+
+func (s *Syncer) existingColumns(ctx context.Context, table string) (map[string]bool, error) {
+	rows, err := s.Conn.Select(ctx,
+		`SELECT column_name FROM information_schema.columns WHERE table_name = ?`, table)
+	if err != nil {
+		return nil, fmt.Errorf("schema: inspecting table %q: %w", table, err)
+	}
+
+	columns := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		if name, ok := row["column_name"].(string); ok {
+			columns[name] = true
+		}
+	}
+	return columns, nil
+}